@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum-optimism/optimistic-specs/l2os"
 	"github.com/ethereum-optimism/optimistic-specs/l2os/flags"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/cliconfig"
 )
 
 var (
@@ -35,7 +36,17 @@ func main() {
 	app.Description = "Service for generating and submitting L2 Output " +
 		"checkpoints to the L2OutputOracle contract"
 
+	app.Before = func(ctx *cli.Context) error {
+		return cliconfig.Load(ctx, flags.ConfigFlag.Name)
+	}
 	app.Action = l2os.Main(Version)
+	app.Commands = []cli.Command{
+		{
+			Name:   "dumpconfig",
+			Usage:  "Print the effective config, as a config file, and check it",
+			Action: l2os.DumpConfig,
+		},
+	}
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
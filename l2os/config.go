@@ -1,11 +1,18 @@
 package l2os
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"time"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli"
 
 	"github.com/ethereum-optimism/optimistic-specs/l2os/flags"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/cliconfig"
 )
 
 type Config struct {
@@ -14,8 +21,8 @@ type Config struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
 
-	// L2EthRpc is the HTTP provider URL for L1.
-	L2EthRpc string
+	// RollupRpc is the HTTP provider URL for the rollup node.
+	RollupRpc string
 
 	// L2OOAddress is the L2OutputOracle contract address.
 	L2OOAddress string
@@ -24,6 +31,45 @@ type Config struct {
 	// and creating a new batch.
 	PollInterval time.Duration
 
+	/* Optional Params */
+
+	// LogLevel is the lowest log level that will be output.
+	LogLevel string
+
+	// L2OOMock binds the L2OutputOracle address as the MockL2OutputOracle,
+	// which does not expose a version.
+	L2OOMock bool
+
+	// Watcher enables checking previously proposed outputs against the
+	// outputs computed by the rollup node.
+	Watcher bool
+
+	// DryRun simulates the next transaction of every driver against L1,
+	// without publishing it, and exits.
+	DryRun bool
+
+	// MetricsEnabled enables serving metrics in the Prometheus format.
+	MetricsEnabled bool
+
+	// MetricsAddr is the listening address of the metrics server.
+	MetricsAddr string
+
+	// MetricsPort is the listening port of the metrics server.
+	MetricsPort int
+
+	/* Driver Params */
+
+	// DriverSections are the config sections of the drivers that run in the
+	// process, in the order they are started in. At least one is required.
+	DriverSections []DriverSection
+}
+
+// DriverConfig is the config section of a single driver. Every driver that
+// runs in the process has its own section, such that drivers use distinct
+// wallets, and their transactions do not interfere.
+type DriverConfig struct {
+	/* Required Params */
+
 	// NumConfirmations is the number of confirmations which we will wait after
 	// appending new batches.
 	NumConfirmations uint64
@@ -37,35 +83,224 @@ type Config struct {
 	// transaction.
 	ResubmissionTimeout time.Duration
 
-	// Mnemonic is the HD seed used to derive the wallet private keys for both
-	// the sequence and proposer. Must be used in conjunction with
-	// SequencerHDPath and ProposerHDPath.
+	/* Optional Params */
+
+	// The wallet is either derived from a mnemonic, decrypted from a
+	// keystore, or held by a remote signer. Exactly one must be configured.
+
+	// Mnemonic is the HD seed used to derive the wallet private key. Must be
+	// used in conjunction with HDPath.
 	Mnemonic string
 
-	// L2OutputHDPath is the derivation path used to obtain the private key for
-	// the l2output transactions.
-	L2OutputHDPath string
+	// HDPath is the derivation path used to obtain the private key for the
+	// transactions of the driver.
+	HDPath string
 
-	/* Optional Params */
+	// Keystore is the encrypted JSON keystore file of the wallet.
+	Keystore string
 
-	// LogLevel is the lowest log level that will be output.
-	LogLevel string
+	// KeystorePassword is the file with the passphrase of the keystore.
+	KeystorePassword string
+
+	// SignerEndpoint is the JSON-RPC endpoint of a remote signer holding the
+	// wallet.
+	SignerEndpoint string
+
+	// SignerAddress is the address of the wallet of the remote signer.
+	SignerAddress string
+
+	// MaxPendingTxs is the maximum number of transactions that are submitted
+	// concurrently when catching up.
+	MaxPendingTxs uint64
+
+	// MaxGasTipCap is the maximum gas tip cap of submitted transactions, in
+	// gwei. Zero means no maximum.
+	MaxGasTipCap uint64
+
+	// MaxGasFeeCap is the maximum gas fee cap of submitted transactions, in
+	// gwei. Zero means no maximum.
+	MaxGasFeeCap uint64
+
+	// FeeBumpPercent is the percentage by which the fees of a transaction are
+	// bumped on every resubmission.
+	FeeBumpPercent uint64
+
+	// FeeHistoryBlocks is the number of recent L1 blocks the base fee is
+	// sampled from.
+	FeeHistoryBlocks uint64
+
+	// JournalPath is the file that published transactions are journaled in
+	// until they confirm. If empty, transactions are not journaled.
+	JournalPath string
+
+	// DeferMaxBaseFee is the L1 base fee, in gwei, above which submissions
+	// are deferred. Zero means submissions are never deferred.
+	DeferMaxBaseFee uint64
+
+	// DeferDeadlinePercent is the percentage of the submission interval
+	// after which a deferred submission is submitted regardless of the base
+	// fee.
+	DeferDeadlinePercent uint64
+
+	// BalanceWarnThreshold is the wallet balance, in ether, below which a
+	// warning is logged. If empty, no warnings are logged.
+	BalanceWarnThreshold string
+
+	// MinBalance is the wallet balance, in ether, below which submissions
+	// are paused. If empty, submissions are never paused.
+	MinBalance string
+}
+
+// DriverSection is the config section of a driver, identified by the label
+// that the driver is registered under.
+type DriverSection struct {
+	Label  string
+	Config DriverConfig
+}
+
+// Check returns an error if the config is invalid. All invalid values are
+// reported at once.
+func (c Config) Check() error {
+	var errs cliconfig.Errors
+	if _, err := log.LvlFromString(c.LogLevel); err != nil {
+		errs.Add(err)
+	}
+	if _, err := parseAddress(c.L2OOAddress); err != nil {
+		errs.Add(fmt.Errorf("invalid L2OutputOracle address: %w", err))
+	}
+	if len(c.DriverSections) == 0 {
+		errs.Add(errors.New("no driver config section is set"))
+	}
+	for _, section := range c.DriverSections {
+		if err := section.Config.Check(); err != nil {
+			errs.Add(fmt.Errorf("%s: %w", section.Label, err))
+		}
+	}
+	return errs.Err()
+}
+
+// Check returns an error if the driver config is invalid. All invalid values
+// are reported at once.
+func (c DriverConfig) Check() error {
+	var errs cliconfig.Errors
+	if c.MaxPendingTxs == 0 {
+		errs.Add(errors.New("max pending txs must be at least 1"))
+	}
+	if c.DeferDeadlinePercent > 100 {
+		errs.Add(errors.New("defer deadline percent must be at most 100"))
+	}
+	if c.FeeBumpPercent < txmgr.MinBumpPercent {
+		errs.Add(fmt.Errorf("fee bump percent must be at least %d",
+			txmgr.MinBumpPercent))
+	}
+	balanceConfig, err := c.BalanceConfig()
+	if err != nil {
+		errs.Add(err)
+	} else {
+		errs.Add(balanceConfig.Check())
+	}
+	return errs.Err()
 }
 
-// NewConfig parses the Config from the provided flags or environment variables.
-func NewConfig(ctx *cli.Context) Config {
-	return Config{
+// BalanceConfig parses the balance thresholds of the wallet.
+func (c DriverConfig) BalanceConfig() (balance.Config, error) {
+	warnThreshold, err := balance.ParseEther(c.BalanceWarnThreshold)
+	if err != nil {
+		return balance.Config{}, fmt.Errorf("invalid balance warning "+
+			"threshold: %w", err)
+	}
+	minBalance, err := balance.ParseEther(c.MinBalance)
+	if err != nil {
+		return balance.Config{}, fmt.Errorf("invalid minimum balance: %w",
+			err)
+	}
+	return balance.Config{
+		WarnThreshold: warnThreshold,
+		MinBalance:    minBalance,
+	}, nil
+}
+
+// NewConfig parses the Config from the provided flags, environment variables
+// or config file. All missing flags, and then all invalid values, are reported
+// at once.
+func NewConfig(ctx *cli.Context) (Config, error) {
+	if err := cliconfig.CheckRequired(ctx, flags.RequiredFlags); err != nil {
+		return Config{}, err
+	}
+	cfg := Config{
+		/* Required Flags */
+		L1EthRpc:     ctx.GlobalString(flags.L1EthRpcFlag.Name),
+		RollupRpc:    ctx.GlobalString(flags.RollupRpcFlag.Name),
+		L2OOAddress:  ctx.GlobalString(flags.L2OOAddressFlag.Name),
+		PollInterval: ctx.GlobalDuration(flags.PollIntervalFlag.Name),
+		/* Optional Flags */
+		LogLevel:       ctx.GlobalString(flags.LogLevelFlag.Name),
+		L2OOMock:       ctx.GlobalBool(flags.L2OOMockFlag.Name),
+		Watcher:        ctx.GlobalBool(flags.WatcherFlag.Name),
+		DryRun:         ctx.GlobalBool(flags.DryRunFlag.Name),
+		MetricsEnabled: ctx.GlobalBool(flags.MetricsEnabledFlag.Name),
+		MetricsAddr:    ctx.GlobalString(flags.MetricsAddrFlag.Name),
+		MetricsPort:    ctx.GlobalInt(flags.MetricsPortFlag.Name),
+	}
+	var errs cliconfig.Errors
+	for _, section := range flags.DriverSections {
+		if !section.IsSet(ctx) {
+			continue
+		}
+		err := cliconfig.CheckRequired(ctx, section.Required())
+		if err != nil {
+			errs.Add(fmt.Errorf("%s: %w", section.Label, err))
+			continue
+		}
+		cfg.DriverSections = append(cfg.DriverSections, DriverSection{
+			Label:  section.Label,
+			Config: newDriverConfig(ctx, section),
+		})
+	}
+	if err := errs.Err(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Check(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// newDriverConfig parses the config section of a driver from its flags.
+func newDriverConfig(ctx *cli.Context, f flags.DriverFlags) DriverConfig {
+	return DriverConfig{
 		/* Required Flags */
-		L1EthRpc:                  ctx.GlobalString(flags.L1EthRpcFlag.Name),
-		L2EthRpc:                  ctx.GlobalString(flags.L2EthRpcFlag.Name),
-		L2OOAddress:               ctx.GlobalString(flags.L2OOAddressFlag.Name),
-		PollInterval:              ctx.GlobalDuration(flags.PollIntervalFlag.Name),
-		NumConfirmations:          ctx.GlobalUint64(flags.NumConfirmationsFlag.Name),
-		SafeAbortNonceTooLowCount: ctx.GlobalUint64(flags.SafeAbortNonceTooLowCountFlag.Name),
-		ResubmissionTimeout:       ctx.GlobalDuration(flags.ResubmissionTimeoutFlag.Name),
-		Mnemonic:                  ctx.GlobalString(flags.MnemonicFlag.Name),
-		L2OutputHDPath:            ctx.GlobalString(flags.L2OutputHDPathFlag.Name),
+		NumConfirmations:          ctx.GlobalUint64(f.NumConfirmations.Name),
+		SafeAbortNonceTooLowCount: ctx.GlobalUint64(f.SafeAbortNonceTooLowCount.Name),
+		ResubmissionTimeout:       ctx.GlobalDuration(f.ResubmissionTimeout.Name),
 		/* Optional Flags */
-		LogLevel: ctx.GlobalString(flags.LogLevelFlag.Name),
+		Mnemonic:             ctx.GlobalString(f.Mnemonic.Name),
+		HDPath:               ctx.GlobalString(f.HDPath.Name),
+		Keystore:             ctx.GlobalString(f.Keystore.Name),
+		KeystorePassword:     ctx.GlobalString(f.KeystorePassword.Name),
+		SignerEndpoint:       ctx.GlobalString(f.SignerEndpoint.Name),
+		SignerAddress:        ctx.GlobalString(f.SignerAddress.Name),
+		MaxPendingTxs:        ctx.GlobalUint64(f.MaxPendingTxs.Name),
+		MaxGasTipCap:         ctx.GlobalUint64(f.MaxGasTipCap.Name),
+		MaxGasFeeCap:         ctx.GlobalUint64(f.MaxGasFeeCap.Name),
+		FeeBumpPercent:       ctx.GlobalUint64(f.FeeBumpPercent.Name),
+		FeeHistoryBlocks:     ctx.GlobalUint64(f.FeeHistoryBlocks.Name),
+		JournalPath:          ctx.GlobalString(f.JournalPath.Name),
+		DeferMaxBaseFee:      ctx.GlobalUint64(f.DeferMaxBaseFee.Name),
+		DeferDeadlinePercent: ctx.GlobalUint64(f.DeferDeadlinePercent.Name),
+		BalanceWarnThreshold: ctx.GlobalString(f.BalanceWarnThreshold.Name),
+		MinBalance:           ctx.GlobalString(f.MinBalance.Name),
+	}
+}
+
+// DumpConfig prints the effective flag values, loaded from the flags,
+// environment variables and config file, and reports all errors of the config
+// at once.
+func DumpConfig(ctx *cli.Context) error {
+	err := cliconfig.Dump(ctx, os.Stdout, flags.ConfigFlag.Name, flags.SecretFlags)
+	if err != nil {
+		return err
 	}
+	_, err = NewConfig(ctx)
+	return err
 }
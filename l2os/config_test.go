@@ -0,0 +1,125 @@
+package l2os
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/flags"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func TestDriverConfigCheck(t *testing.T) {
+	valid := DriverConfig{
+		MaxPendingTxs:        1,
+		FeeBumpPercent:       10,
+		DeferDeadlinePercent: 100,
+	}
+	require.NoError(t, valid.Check())
+
+	cfg := valid
+	cfg.MaxPendingTxs = 0
+	require.Error(t, cfg.Check())
+
+	cfg = valid
+	cfg.FeeBumpPercent = 9
+	require.Error(t, cfg.Check())
+
+	cfg = valid
+	cfg.DeferDeadlinePercent = 101
+	require.Error(t, cfg.Check())
+}
+
+func TestDriverConfigBalance(t *testing.T) {
+	cfg := DriverConfig{
+		MaxPendingTxs:        1,
+		FeeBumpPercent:       10,
+		BalanceWarnThreshold: "1",
+		MinBalance:           "0.1",
+	}
+	require.NoError(t, cfg.Check())
+	balanceConfig, err := cfg.BalanceConfig()
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(params.Ether), balanceConfig.WarnThreshold)
+	require.Equal(t, big.NewInt(params.Ether/10), balanceConfig.MinBalance)
+
+	cfg.MinBalance = "2"
+	require.Error(t, cfg.Check())
+
+	cfg.MinBalance = "0.1 ether"
+	require.Error(t, cfg.Check())
+}
+
+func TestConfigCheckReportsAllErrors(t *testing.T) {
+	cfg := Config{
+		LogLevel:    "loud",
+		L2OOAddress: "0x1234",
+		DriverSections: []DriverSection{{
+			Label:  "l2output",
+			Config: DriverConfig{FeeBumpPercent: 10},
+		}},
+	}
+	err := cfg.Check()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "3 errors")
+	require.Contains(t, err.Error(), "invalid L2OutputOracle address")
+	require.Contains(t, err.Error(), "l2output: max pending txs")
+
+	cfg = Config{
+		LogLevel:    "info",
+		L2OOAddress: "0x0000000000000000000000000000000000000001",
+		DriverSections: []DriverSection{{
+			Label: "l2output",
+			Config: DriverConfig{
+				MaxPendingTxs:  1,
+				FeeBumpPercent: 10,
+			},
+		}},
+	}
+	require.NoError(t, cfg.Check())
+
+	cfg.DriverSections = nil
+	require.EqualError(t, cfg.Check(), "no driver config section is set")
+}
+
+func TestNewConfigDriverSections(t *testing.T) {
+	newConfig := func(args ...string) (Config, error) {
+		var cfg Config
+		app := cli.NewApp()
+		app.Flags = flags.Flags
+		app.Action = func(ctx *cli.Context) error {
+			var err error
+			cfg, err = NewConfig(ctx)
+			return err
+		}
+		err := app.Run(append([]string{"l2os",
+			"--l1-eth-rpc", "http://localhost:8545",
+			"--rollup-rpc", "http://localhost:7545",
+			"--l2oo-address", "0x0000000000000000000000000000000000000001",
+			"--poll-interval", "1s",
+		}, args...))
+		return cfg, err
+	}
+
+	cfg, err := newConfig(
+		"--l2output.num-confirmations", "2",
+		"--l2output.safe-abort-nonce-too-low-count", "3",
+		"--l2output.resubmission-timeout", "30s",
+		"--l2output.journal-path", "/tmp/journal.json",
+	)
+	require.NoError(t, err)
+	require.Len(t, cfg.DriverSections, 1)
+	section := cfg.DriverSections[0]
+	require.Equal(t, "l2output", section.Label)
+	require.Equal(t, uint64(2), section.Config.NumConfirmations)
+	require.Equal(t, "/tmp/journal.json", section.Config.JournalPath)
+	require.Equal(t, uint64(10), section.Config.MaxPendingTxs)
+
+	_, err = newConfig("--l2output.journal-path", "/tmp/journal.json")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "l2output: missing required flags")
+
+	_, err = newConfig()
+	require.EqualError(t, err, "no driver config section is set")
+}
@@ -34,3 +34,18 @@ type L1Client interface {
 	// hash. Note that the receipt is not available for pending transactions.
 	TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error)
 }
+
+// BlockRange is a range of L2 block heights that is processed by a single
+// transaction. Note that the end value is *exclusive*.
+type BlockRange struct {
+	Start *big.Int
+	End   *big.Int
+
+	// DueTimestamp is the L1 timestamp from which the range can be
+	// processed.
+	DueTimestamp uint64
+
+	// Interval is the number of seconds between the due timestamps of
+	// consecutive ranges.
+	Interval uint64
+}
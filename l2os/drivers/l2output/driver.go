@@ -2,63 +2,66 @@ package l2output
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
-	"strings"
 
-	"github.com/ethereum-optimism/optimistic-specs/l2os/bindings/l2oo"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 var bigOne = big.NewInt(1)
 
+// maxBlockHashAge is the number of most recent L1 blocks whose hashes are
+// available to the BLOCKHASH opcode.
+const maxBlockHashAge = 256
+
+// RollupClient is the rollup node that the L2 outputs and the safety of the
+// L2 blocks are sourced from.
+type RollupClient interface {
+	// OutputAtBlock computes the versioned L2 output root of an L2 block.
+	OutputAtBlock(ctx context.Context, number *big.Int) (version l2.Bytes32, root l2.Bytes32, err error)
+
+	// SyncStatus returns the current L1 and L2 heads of the rollup node.
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
 type Config struct {
-	Name     string
-	L1Client *ethclient.Client
-	L2Client *ethclient.Client
-	L2OOAddr common.Address
-	ChainID  *big.Int
-	PrivKey  *ecdsa.PrivateKey
+	Name         string
+	L1Client     *ethclient.Client
+	RollupClient RollupClient
+	L2OOAddr     common.Address
+	Oracle       OutputOracle
+	ChainID      *big.Int
+	Signer       signer.Signer
 }
 
 type Driver struct {
 	cfg             Config
-	l2ooContract    *l2oo.MockL2OutputOracle
+	l2ooContract    OutputOracle
 	rawL2ooContract *bind.BoundContract
 	walletAddr      common.Address
 }
 
 func NewDriver(cfg Config) (*Driver, error) {
-	l2ooContract, err := l2oo.NewMockL2OutputOracle(
-		cfg.L2OOAddr, cfg.L1Client,
-	)
-	if err != nil {
-		return nil, err
-	}
-
-	parsed, err := abi.JSON(strings.NewReader(
-		l2oo.MockL2OutputOracleMetaData.ABI,
-	))
-	if err != nil {
-		return nil, err
-	}
-
+	// The raw contract only re-signs crafted transactions, so it does not
+	// depend on the ABI of the oracle.
 	rawL2ooContract := bind.NewBoundContract(
-		cfg.L2OOAddr, parsed, cfg.L1Client, cfg.L1Client, cfg.L1Client,
+		cfg.L2OOAddr, abi.ABI{}, cfg.L1Client, cfg.L1Client, cfg.L1Client,
 	)
 
-	walletAddr := crypto.PubkeyToAddress(cfg.PrivKey.PublicKey)
+	walletAddr := cfg.Signer.Address()
 
 	return &Driver{
 		cfg:             cfg,
-		l2ooContract:    l2ooContract,
+		l2ooContract:    cfg.Oracle,
 		rawL2ooContract: rawL2ooContract,
 		walletAddr:      walletAddr,
 	}, nil
@@ -74,11 +77,13 @@ func (d *Driver) WalletAddr() common.Address {
 	return d.walletAddr
 }
 
-// GetBlockRange returns the start and end L2 block heights that need to be
-// processed. Note that the end value is *exclusive*, therefore if the returned
-// values are identical nothing needs to be processed.
-func (d *Driver) GetBlockRange(
-	ctx context.Context) (*big.Int, *big.Int, error) {
+// GetBlockRanges returns the consecutive ranges of L2 block heights that need
+// to be processed, in order, with at most max ranges. Every range ends at the
+// L2 block of a checkpoint whose submission interval has elapsed. Note that
+// the end values are *exclusive*, and if no ranges are returned nothing needs
+// to be processed.
+func (d *Driver) GetBlockRanges(
+	ctx context.Context, max int) ([]drivers.BlockRange, error) {
 
 	name := d.cfg.Name
 
@@ -93,12 +98,12 @@ func (d *Driver) GetBlockRange(
 	l2ooTimestamp, err := d.l2ooContract.LatestBlockTimestamp(callOpts)
 	if err != nil {
 		log.Error(name+" unable to get latest block timestamp", "err", err)
-		return nil, nil, err
+		return nil, err
 	}
 	start, err := d.l2ooContract.ComputeL2BlockNumber(callOpts, l2ooTimestamp)
 	if err != nil {
 		log.Error(name+" unable to compute latest l2 block number", "err", err)
-		return nil, nil, err
+		return nil, err
 	}
 	start.Add(start, bigOne)
 
@@ -109,38 +114,74 @@ func (d *Driver) GetBlockRange(
 	nextTimestamp, err := d.l2ooContract.NextTimestamp(callOpts)
 	if err != nil {
 		log.Error(name+" unable to get next block timestamp", "err", err)
-		return nil, nil, err
+		return nil, err
+	}
+	submissionFrequency, err := d.l2ooContract.SubmissionFrequency(callOpts)
+	if err != nil {
+		log.Error(name+" unable to get submission frequency", "err", err)
+		return nil, err
 	}
 	latestHeader, err := d.cfg.L1Client.HeaderByNumber(ctx, nil)
 	if err != nil {
 		log.Error(name+" unable to retrieve latest header", "err", err)
-		return nil, nil, err
+		return nil, err
 	}
 	currentTimestamp := big.NewInt(int64(latestHeader.Time))
 
-	// If the submission window has yet to elapsed, we must wait before
-	// submitting our L2 output commitment. Return start as the end value which
-	// will signal that there is no work to be done.
-	if currentTimestamp.Cmp(nextTimestamp) < 0 {
-		log.Info(name+" submission interval has not elapsed",
-			"currentTimestamp", currentTimestamp, "nextTimestamp", nextTimestamp)
-		return start, start, nil
+	// Only propose outputs of L2 blocks that the rollup node has derived
+	// from L1, up to its safe head. Otherwise wait for the rollup node to
+	// derive the block.
+	status, err := d.cfg.RollupClient.SyncStatus(ctx)
+	if err != nil {
+		log.Error(name+" unable to get rollup node sync status", "err", err)
+		return nil, err
+	}
+	safeHead := status.SafeL2
+
+	// Collect the checkpoints that can be submitted, such that a submitter
+	// that fell behind can catch up with multiple outputs at once.
+	var ranges []drivers.BlockRange
+	for len(ranges) < max {
+		// If the submission window has yet to elapsed, we must wait before
+		// submitting our L2 output commitment.
+		if currentTimestamp.Cmp(nextTimestamp) < 0 {
+			log.Info(name+" submission interval has not elapsed",
+				"currentTimestamp", currentTimestamp,
+				"nextTimestamp", nextTimestamp)
+			break
+		}
+
+		// Otherwise the submission interval has elapsed. Transform the next
+		// expected timestamp into its L2 block number, and add one since end
+		// is exclusive.
+		end, err := d.l2ooContract.ComputeL2BlockNumber(callOpts, nextTimestamp)
+		if err != nil {
+			log.Error(name+" unable to compute next l2 block number", "err", err)
+			return nil, err
+		}
+		if end.Uint64() > safeHead.Number {
+			log.Info(name+" next checkpoint block is not safe yet",
+				"block", end, "safeHead", safeHead)
+			break
+		}
+		end.Add(end, bigOne)
+
+		ranges = append(ranges, drivers.BlockRange{
+			Start:        start,
+			End:          end,
+			DueTimestamp: nextTimestamp.Uint64(),
+			Interval:     submissionFrequency.Uint64(),
+		})
+		start = end
+		nextTimestamp = new(big.Int).Add(nextTimestamp, submissionFrequency)
 	}
 
-	log.Info(name+" submission interval has elapsed",
-		"currentTimestamp", currentTimestamp, "nextTimestamp", nextTimestamp)
-
-	// Otherwise the submission interval has elapsed. Transform the next
-	// expected timestamp into its L2 block number, and add one since end is
-	// exclusive.
-	end, err := d.l2ooContract.ComputeL2BlockNumber(callOpts, nextTimestamp)
-	if err != nil {
-		log.Error(name+" unable to compute next l2 block number", "err", err)
-		return nil, nil, err
+	if len(ranges) > 0 {
+		log.Info(name+" submission interval has elapsed",
+			"checkpoints", len(ranges))
 	}
-	end.Add(end, bigOne)
 
-	return start, end, nil
+	return ranges, nil
 }
 
 // CraftTx transforms the L2 blocks between start and end into a transaction
@@ -157,79 +198,236 @@ func (d *Driver) CraftTx(
 	log.Info(name+" crafting checkpoint tx", "start", start, "end", end,
 		"nonce", nonce)
 
-	// Fetch the final block in the range, as this is the only L2 output we need
-	// to submit.
+	// Compute the output root of the final block in the range, as this is the
+	// only L2 output we need to submit.
 	nextCheckpointBlock := new(big.Int).Sub(end, bigOne)
-	checkpointBlock, err := d.cfg.L2Client.HeaderByNumber(
+	outputVersion, l2OutputRoot, err := d.cfg.RollupClient.OutputAtBlock(
 		ctx, nextCheckpointBlock,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if outputVersion != l2.OutputRootVersionV0 {
+		return nil, fmt.Errorf("unsupported L2 output version: %s",
+			outputVersion)
+	}
 
-	// Fetch the next expected timestamp that we will submit along with the
-	// L2Output.
+	// Compute the timestamp of the checkpoint block that we will submit along
+	// with the L2Output.
 	callOpts := &bind.CallOpts{
 		Pending: false,
 		Context: ctx,
 	}
-	timestamp, err := d.l2ooContract.NextTimestamp(callOpts)
+	timestamp, err := d.checkpointTimestamp(callOpts, nextCheckpointBlock)
 	if err != nil {
 		return nil, err
 	}
 
-	// Sanity check that we are submitting against the same expected timestamp.
-	expCheckpointBlock, err := d.l2ooContract.ComputeL2BlockNumber(
-		callOpts, timestamp,
-	)
+	// Sanity check that the checkpoint is not committed yet, and is aligned
+	// with the submission frequency. Checkpoints after the next expected
+	// timestamp can only be appended after the pending checkpoints before
+	// them.
+	nextTimestamp, err := d.l2ooContract.NextTimestamp(callOpts)
 	if err != nil {
 		return nil, err
 	}
-	if nextCheckpointBlock.Cmp(expCheckpointBlock) != 0 {
-		return nil, fmt.Errorf("expected next checkpoint block to be %d, "+
-			"found %d", nextCheckpointBlock.Uint64(),
-			expCheckpointBlock.Uint64())
+	submissionFrequency, err := d.l2ooContract.SubmissionFrequency(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if timestamp.Cmp(nextTimestamp) < 0 {
+		return nil, fmt.Errorf("checkpoint block %d is already committed, "+
+			"next timestamp is %d", nextCheckpointBlock.Uint64(),
+			nextTimestamp.Uint64())
+	}
+	pendingInterval := new(big.Int).Sub(timestamp, nextTimestamp)
+	if new(big.Int).Mod(pendingInterval, submissionFrequency).Sign() != 0 {
+		return nil, fmt.Errorf("checkpoint block %d at timestamp %d is not "+
+			"aligned with next timestamp %d", nextCheckpointBlock.Uint64(),
+			timestamp.Uint64(), nextTimestamp.Uint64())
+	}
+
+	// Bind the output to the L1 block that the rollup node derived the safe
+	// L2 head from, such that the output is not appended if that block is
+	// reorged out before the tx is included.
+	status, err := d.cfg.RollupClient.SyncStatus(ctx)
+	if err != nil {
+		return nil, err
 	}
+	if status.SafeL2.Number < nextCheckpointBlock.Uint64() {
+		return nil, fmt.Errorf("checkpoint block %d is not safe, safe head "+
+			"is %d", nextCheckpointBlock.Uint64(), status.SafeL2.Number)
+	}
+	l1Block := status.CurrentL1
 
 	numElements := new(big.Int).Sub(start, end).Uint64()
 	log.Info(name+" checkpoint constructed", "start", start, "end", end,
-		"nonce", nonce, "blocks_committed", numElements)
+		"nonce", nonce, "blocks_committed", numElements,
+		"output_root", l2OutputRoot, "l1_block", l1Block)
 
-	opts, err := bind.NewKeyedTransactorWithChainID(
-		d.cfg.PrivKey, d.cfg.ChainID,
-	)
+	opts := signer.TransactOpts(ctx, d.cfg.Signer, d.cfg.ChainID)
+	opts.NoSend = true
+
+	// The gas is estimated against the pending state, in which a checkpoint
+	// reverts if the pending checkpoints before it are not included yet.
+	// Every checkpoint costs the same to append, so estimate the gas of
+	// appending the output at the next expected timestamp of the pending
+	// state instead.
+	pendingNextTimestamp, err := d.l2ooContract.NextTimestamp(&bind.CallOpts{
+		Pending: true,
+		Context: ctx,
+	})
 	if err != nil {
 		return nil, err
 	}
-	opts.Context = ctx
+	if timestamp.Cmp(pendingNextTimestamp) != 0 {
+		estimateTx, err := d.l2ooContract.AppendL2Output(
+			opts, l2OutputRoot, pendingNextTimestamp, l1Block,
+		)
+		if err != nil {
+			return nil, err
+		}
+		opts.GasLimit = estimateTx.Gas()
+	}
 	opts.Nonce = nonce
-	opts.NoSend = true
 
 	return d.l2ooContract.AppendL2Output(
-		opts, checkpointBlock.Root, timestamp,
+		opts, l2OutputRoot, timestamp, l1Block,
+	)
+}
+
+// checkpointTimestamp transforms the L2 block number of a checkpoint into its
+// timestamp, the inverse of ComputeL2BlockNumber.
+func (d *Driver) checkpointTimestamp(
+	callOpts *bind.CallOpts, checkpointBlock *big.Int) (*big.Int, error) {
+
+	startingTimestamp, err := d.l2ooContract.StartingBlockTimestamp(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	historicalTotalBlocks, err := d.l2ooContract.HistoricalTotalBlocks(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	l2BlockTime, err := d.l2ooContract.L2BlockTime(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if checkpointBlock.Cmp(historicalTotalBlocks) < 0 {
+		return nil, fmt.Errorf("checkpoint block %d precedes the L2 chain "+
+			"starting at %d", checkpointBlock.Uint64(),
+			historicalTotalBlocks.Uint64())
+	}
+
+	timestamp := new(big.Int).Sub(checkpointBlock, historicalTotalBlocks)
+	timestamp.Mul(timestamp, l2BlockTime)
+	timestamp.Add(timestamp, startingTimestamp)
+
+	// Sanity check that the timestamp maps back onto the checkpoint block.
+	expCheckpointBlock, err := d.l2ooContract.ComputeL2BlockNumber(
+		callOpts, timestamp,
 	)
+	if err != nil {
+		return nil, err
+	}
+	if checkpointBlock.Cmp(expCheckpointBlock) != 0 {
+		return nil, fmt.Errorf("expected checkpoint block to be %d, "+
+			"found %d", checkpointBlock.Uint64(),
+			expCheckpointBlock.Uint64())
+	}
+
+	return timestamp, nil
 }
 
 // UpdateGasPrice signs an otherwise identical txn to the one provided but with
-// updated gas prices sampled from the existing network conditions.
+// the given gas tip cap and gas fee cap.
 //
 // NOTE: Thie method SHOULD NOT publish the resulting transaction.
 func (d *Driver) UpdateGasPrice(
 	ctx context.Context,
 	tx *types.Transaction,
+	gasTipCap, gasFeeCap *big.Int,
 ) (*types.Transaction, error) {
 
-	opts, err := bind.NewKeyedTransactorWithChainID(
-		d.cfg.PrivKey, d.cfg.ChainID,
+	opts := signer.TransactOpts(ctx, d.cfg.Signer, d.cfg.ChainID)
+	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
+	opts.GasLimit = tx.Gas()
+	opts.GasTipCap = gasTipCap
+	opts.GasFeeCap = gasFeeCap
+	opts.NoSend = true
+
+	return d.rawL2ooContract.RawTransact(opts, tx.Data())
+}
+
+// IsTxStale returns whether the L1 block that the tx binds its output to is no
+// longer canonical, or too old for the oracle to check its hash, such that
+// the tx would revert and must be crafted again.
+func (d *Driver) IsTxStale(
+	ctx context.Context,
+	tx *types.Transaction,
+) (bool, error) {
+
+	name := d.cfg.Name
+
+	l1Block, err := d.l2ooContract.UnpackL1Block(tx.Data())
+	if err != nil {
+		return false, err
+	}
+	if l1Block == (eth.BlockID{}) {
+		return false, nil
+	}
+
+	header, err := d.cfg.L1Client.HeaderByNumber(
+		ctx, new(big.Int).SetUint64(l1Block.Number),
 	)
+	if err != nil {
+		return false, err
+	}
+	if header.Hash() != l1Block.Hash {
+		log.Warn(name+" L1 block of tx was reorged out", "tx", tx.Hash(),
+			"l1_block", l1Block, "canonical", header.Hash())
+		return true, nil
+	}
+
+	// The oracle can only check the hashes of the most recent L1 blocks.
+	latestHeader, err := d.cfg.L1Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	if latestHeader.Number.Uint64() >= l1Block.Number+maxBlockHashAge {
+		log.Warn(name+" L1 block of tx is too old", "tx", tx.Hash(),
+			"l1_block", l1Block, "latest", latestHeader.Number)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// DecodeTx decodes the call data of a crafted tx into the method and its
+// arguments, as log context.
+func (d *Driver) DecodeTx(tx *types.Transaction) ([]interface{}, error) {
+	data := tx.Data()
+	if len(data) < 4 {
+		return nil, fmt.Errorf("call data too short: %d bytes", len(data))
+	}
+	method, err := d.l2ooContract.ABI().MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(data[4:])
 	if err != nil {
 		return nil, err
 	}
-	opts.Context = ctx
-	opts.Nonce = new(big.Int).SetUint64(tx.Nonce())
-	opts.NoSend = true
 
-	return d.rawL2ooContract.RawTransact(opts, tx.Data())
+	decoded := []interface{}{"method", method.Name}
+	for i, input := range method.Inputs {
+		arg := args[i]
+		if b, ok := arg.([32]byte); ok {
+			arg = common.Hash(b)
+		}
+		decoded = append(decoded, input.Name, arg)
+	}
+	return decoded, nil
 }
 
 // SendTransaction injects a signed transaction into the pending pool for
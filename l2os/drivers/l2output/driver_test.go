@@ -0,0 +1,46 @@
+package l2output
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriverDecodeTx(t *testing.T) {
+	backend, opts := newSimulatedBackend(t)
+
+	oracle, err := NewOutputOracle(
+		context.Background(), supportedOracleAddr, backend, false,
+	)
+	require.NoError(t, err)
+	d := &Driver{l2ooContract: oracle}
+
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 42}
+	opts.NoSend = true
+	tx, err := oracle.AppendL2Output(
+		opts, [32]byte{0x02}, big.NewInt(1000), l1Block,
+	)
+	require.NoError(t, err)
+
+	decoded, err := d.DecodeTx(tx)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		"method", "appendL2Output",
+		"_l2Output", common.Hash{0x02},
+		"_l2timestamp", big.NewInt(1000),
+		"_l1Blockhash", l1Block.Hash,
+		"_l1Blocknumber", big.NewInt(42),
+	}, decoded)
+
+	_, err = d.DecodeTx(types.NewTx(&types.DynamicFeeTx{Data: []byte{0x01}}))
+	require.Error(t, err)
+	_, err = d.DecodeTx(types.NewTx(&types.DynamicFeeTx{
+		Data: []byte{0x01, 0x02, 0x03, 0x04},
+	}))
+	require.Error(t, err)
+}
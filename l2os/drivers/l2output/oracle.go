@@ -0,0 +1,231 @@
+package l2output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/bindings/l2oo"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// SupportedOracleMajorVersion is the major version of the L2OutputOracle
+// interface that the driver supports.
+const SupportedOracleMajorVersion = 1
+
+// MockOracleVersion is the version reported for the MockL2OutputOracle,
+// which does not expose a version.
+const MockOracleVersion = "mock"
+
+// OutputOracle is the L1 contract that L2 outputs are submitted to.
+type OutputOracle interface {
+	// LatestBlockTimestamp returns the L2 timestamp of the latest output.
+	LatestBlockTimestamp(opts *bind.CallOpts) (*big.Int, error)
+
+	// NextTimestamp returns the L2 timestamp of the next expected output.
+	NextTimestamp(opts *bind.CallOpts) (*big.Int, error)
+
+	// SubmissionFrequency returns the number of seconds between outputs.
+	SubmissionFrequency(opts *bind.CallOpts) (*big.Int, error)
+
+	// StartingBlockTimestamp returns the L2 timestamp of the first output.
+	StartingBlockTimestamp(opts *bind.CallOpts) (*big.Int, error)
+
+	// HistoricalTotalBlocks returns the number of L2 blocks preceding the
+	// first output.
+	HistoricalTotalBlocks(opts *bind.CallOpts) (*big.Int, error)
+
+	// L2BlockTime returns the number of seconds between L2 blocks.
+	L2BlockTime(opts *bind.CallOpts) (*big.Int, error)
+
+	// ComputeL2BlockNumber transforms an L2 timestamp into its block number.
+	ComputeL2BlockNumber(opts *bind.CallOpts, timestamp *big.Int) (*big.Int, error)
+
+	// L2Outputs returns the output appended at the given L2 timestamp.
+	L2Outputs(opts *bind.CallOpts, timestamp *big.Int) ([32]byte, error)
+
+	// AppendL2Output crafts a transaction that appends the L2 output of the
+	// block at the given L2 timestamp. The L1 block binds the output to the
+	// L1 chain it was computed against: the transaction reverts if the L1
+	// block is not canonical. A zero L1 block skips the check. Oracles
+	// without reorg protection ignore the L1 block.
+	AppendL2Output(
+		opts *bind.TransactOpts,
+		l2Output [32]byte,
+		timestamp *big.Int,
+		l1Block eth.BlockID,
+	) (*types.Transaction, error)
+
+	// UnpackL1Block returns the L1 block that the appendL2Output call data
+	// binds the output to, or a zero block if there is none.
+	UnpackL1Block(data []byte) (eth.BlockID, error)
+
+	// ABI returns the ABI of the oracle interface.
+	ABI() *abi.ABI
+
+	// Version returns the version of the oracle interface.
+	Version() string
+}
+
+// NewOutputOracle binds the oracle at the given address, after checking that
+// its version is supported. If mock is set, the oracle is bound as the
+// MockL2OutputOracle instead, which does not expose a version.
+func NewOutputOracle(
+	ctx context.Context,
+	addr common.Address,
+	backend bind.ContractBackend,
+	mock bool,
+) (OutputOracle, error) {
+
+	if mock {
+		log.Warn("Binding L2 output oracle as MockL2OutputOracle, "+
+			"without reorg protection", "address", addr)
+		contract, err := l2oo.NewMockL2OutputOracle(addr, backend)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := l2oo.MockL2OutputOracleMetaData.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		return &mockOutputOracle{
+			MockL2OutputOracle: contract,
+			abi:                parsed,
+		}, nil
+	}
+
+	contract, err := l2oo.NewL2OutputOracle(addr, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := contract.Version(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read L2 output oracle version: %w",
+			err)
+	}
+
+	if err := CheckOracleVersion(version); err != nil {
+		return nil, err
+	}
+	log.Info("Bound L2 output oracle", "address", addr, "version", version)
+
+	parsed, err := l2oo.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &outputOracle{
+		L2OutputOracle: contract,
+		abi:            parsed,
+		version:        version,
+	}, nil
+}
+
+// CheckOracleVersion returns an error if the semver version of the oracle is
+// not compatible with SupportedOracleMajorVersion.
+func CheckOracleVersion(version string) error {
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid L2 output oracle version %q", version)
+	}
+	var numbers [3]uint64
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid L2 output oracle version %q", version)
+		}
+		numbers[i] = n
+	}
+	if numbers[0] != SupportedOracleMajorVersion {
+		return fmt.Errorf("unsupported L2 output oracle version %q, "+
+			"expected major version %d", version,
+			SupportedOracleMajorVersion)
+	}
+	return nil
+}
+
+// outputOracle is the L2OutputOracle, with reorg protection.
+type outputOracle struct {
+	*l2oo.L2OutputOracle
+	abi     *abi.ABI
+	version string
+}
+
+func (o *outputOracle) AppendL2Output(
+	opts *bind.TransactOpts,
+	l2Output [32]byte,
+	timestamp *big.Int,
+	l1Block eth.BlockID,
+) (*types.Transaction, error) {
+
+	return o.L2OutputOracle.AppendL2Output(
+		opts, l2Output, timestamp, l1Block.Hash,
+		new(big.Int).SetUint64(l1Block.Number),
+	)
+}
+
+func (o *outputOracle) UnpackL1Block(data []byte) (eth.BlockID, error) {
+	method := o.abi.Methods["appendL2Output"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return eth.BlockID{}, errors.New("not an appendL2Output call")
+	}
+	args, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	hash, ok := args[2].([32]byte)
+	if !ok {
+		return eth.BlockID{}, errors.New("invalid L1 block hash argument")
+	}
+	number, ok := args[3].(*big.Int)
+	if !ok || !number.IsUint64() {
+		return eth.BlockID{}, errors.New("invalid L1 block number argument")
+	}
+	return eth.BlockID{Hash: hash, Number: number.Uint64()}, nil
+}
+
+func (o *outputOracle) ABI() *abi.ABI {
+	return o.abi
+}
+
+func (o *outputOracle) Version() string {
+	return o.version
+}
+
+// mockOutputOracle is the MockL2OutputOracle, without reorg protection.
+type mockOutputOracle struct {
+	*l2oo.MockL2OutputOracle
+	abi *abi.ABI
+}
+
+func (o *mockOutputOracle) AppendL2Output(
+	opts *bind.TransactOpts,
+	l2Output [32]byte,
+	timestamp *big.Int,
+	l1Block eth.BlockID,
+) (*types.Transaction, error) {
+
+	return o.MockL2OutputOracle.AppendL2Output(opts, l2Output, timestamp)
+}
+
+func (o *mockOutputOracle) UnpackL1Block(data []byte) (eth.BlockID, error) {
+	return eth.BlockID{}, nil
+}
+
+func (o *mockOutputOracle) ABI() *abi.ABI {
+	return o.abi
+}
+
+func (o *mockOutputOracle) Version() string {
+	return MockOracleVersion
+}
@@ -0,0 +1,260 @@
+package l2output
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/bindings/l2oo"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// versionCode returns runtime code that returns the ABI encoding of the given
+// string for any call, standing in for the version of an L2OutputOracle.
+func versionCode(version string) []byte {
+	var data [32]byte
+	copy(data[:], version)
+
+	code := []byte{
+		0x60, 0x20, 0x60, 0x00, 0x52, // mstore(0x00, 0x20)
+		0x60, byte(len(version)), 0x60, 0x20, 0x52, // mstore(0x20, len)
+		0x7f, // push32 data
+	}
+	code = append(code, data[:]...)
+	code = append(code,
+		0x60, 0x40, 0x52, // mstore(0x40, data)
+		0x60, 0x60, 0x60, 0x00, 0xf3, // return(0x00, 0x60)
+	)
+	return code
+}
+
+var (
+	supportedOracleAddr   = common.Address{0xaa, 0x01}
+	unsupportedOracleAddr = common.Address{0xaa, 0x02}
+	invalidOracleAddr     = common.Address{0xaa, 0x03}
+	emptyAddr             = common.Address{0xaa, 0x04}
+)
+
+func newSimulatedBackend(t *testing.T) (*backends.SimulatedBackend, *bind.TransactOpts) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		opts.From:             {Balance: big.NewInt(params.Ether)},
+		supportedOracleAddr:   {Code: versionCode("1.2.0"), Balance: common.Big0},
+		unsupportedOracleAddr: {Code: versionCode("2.0.0"), Balance: common.Big0},
+		invalidOracleAddr:     {Code: versionCode("v1"), Balance: common.Big0},
+	}, 15_000_000)
+	t.Cleanup(func() { backend.Close() })
+	return backend, opts
+}
+
+func TestNewOutputOracleVersion(t *testing.T) {
+	backend, _ := newSimulatedBackend(t)
+	ctx := context.Background()
+
+	oracle, err := NewOutputOracle(ctx, supportedOracleAddr, backend, false)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", oracle.Version())
+
+	_, err = NewOutputOracle(ctx, unsupportedOracleAddr, backend, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported L2 output oracle version")
+
+	_, err = NewOutputOracle(ctx, invalidOracleAddr, backend, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "invalid L2 output oracle version")
+
+	_, err = NewOutputOracle(ctx, emptyAddr, backend, false)
+	require.Error(t, err)
+}
+
+func TestOutputOracleAppendL2Output(t *testing.T) {
+	backend, opts := newSimulatedBackend(t)
+
+	oracle, err := NewOutputOracle(
+		context.Background(), supportedOracleAddr, backend, false,
+	)
+	require.NoError(t, err)
+
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 42}
+	opts.NoSend = true
+	tx, err := oracle.AppendL2Output(
+		opts, [32]byte{0x02}, big.NewInt(1000), l1Block,
+	)
+	require.NoError(t, err)
+
+	// The L1 block is passed to the oracle for reorg protection.
+	parsed, err := abi.JSON(strings.NewReader(l2oo.L2OutputOracleMetaData.ABI))
+	require.NoError(t, err)
+	args, err := parsed.Methods["appendL2Output"].Inputs.Unpack(tx.Data()[4:])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[32]byte{0x02}, big.NewInt(1000), [32]byte(l1Block.Hash),
+		new(big.Int).SetUint64(l1Block.Number),
+	}, args)
+}
+
+func TestOutputOracleUnpackL1Block(t *testing.T) {
+	backend, opts := newSimulatedBackend(t)
+
+	oracle, err := NewOutputOracle(
+		context.Background(), supportedOracleAddr, backend, false,
+	)
+	require.NoError(t, err)
+
+	l1Block := eth.BlockID{Hash: common.Hash{0x01}, Number: 42}
+	opts.NoSend = true
+	tx, err := oracle.AppendL2Output(
+		opts, [32]byte{0x02}, big.NewInt(1000), l1Block,
+	)
+	require.NoError(t, err)
+
+	unpacked, err := oracle.UnpackL1Block(tx.Data())
+	require.NoError(t, err)
+	require.Equal(t, l1Block, unpacked)
+
+	_, err = oracle.UnpackL1Block([]byte{0x01, 0x02})
+	require.Error(t, err)
+	_, err = oracle.UnpackL1Block(tx.Data()[:40])
+	require.Error(t, err)
+}
+
+func TestMockOutputOracle(t *testing.T) {
+	backend, opts := newSimulatedBackend(t)
+
+	addr, _, _, err := l2oo.DeployMockL2OutputOracle(
+		opts, backend, big.NewInt(10), big.NewInt(2), [32]byte{0x01},
+		big.NewInt(0),
+	)
+	require.NoError(t, err)
+	backend.Commit()
+
+	// The mock has no version, and is only bound as the mock when opted in.
+	_, err = NewOutputOracle(context.Background(), addr, backend, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(),
+		"unable to read L2 output oracle version")
+
+	// The mock is bound without reorg protection.
+	oracle, err := NewOutputOracle(context.Background(), addr, backend, true)
+	require.NoError(t, err)
+	require.Equal(t, MockOracleVersion, oracle.Version())
+
+	callOpts := &bind.CallOpts{}
+	nextTimestamp, err := oracle.NextTimestamp(callOpts)
+	require.NoError(t, err)
+	require.NoError(t, backend.AdjustTime(20*time.Second))
+	backend.Commit()
+
+	_, err = oracle.AppendL2Output(
+		opts, [32]byte{0x02}, nextTimestamp,
+		eth.BlockID{Hash: common.Hash{0x03}, Number: 1},
+	)
+	require.NoError(t, err)
+	backend.Commit()
+
+	// Without reorg protection, txs never reference an L1 block.
+	l1Block, err := oracle.UnpackL1Block(nil)
+	require.NoError(t, err)
+	require.Equal(t, eth.BlockID{}, l1Block)
+
+	latest, err := oracle.LatestBlockTimestamp(callOpts)
+	require.NoError(t, err)
+	require.Equal(t, nextTimestamp, latest)
+	output, err := oracle.L2Outputs(callOpts, nextTimestamp)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{0x02}, output)
+}
+
+func TestL2OutputOracle(t *testing.T) {
+	backend, opts := newSimulatedBackend(t)
+	ctx := context.Background()
+
+	addr, _, _, err := l2oo.DeployL2OutputOracle(
+		opts, backend, big.NewInt(10), big.NewInt(2), [32]byte{0x01},
+		big.NewInt(100), opts.From,
+	)
+	require.NoError(t, err)
+	backend.Commit()
+
+	oracle, err := NewOutputOracle(ctx, addr, backend, false)
+	require.NoError(t, err)
+	require.Equal(t, "1.0.0", oracle.Version())
+
+	callOpts := &bind.CallOpts{Context: ctx}
+	start, err := oracle.StartingBlockTimestamp(callOpts)
+	require.NoError(t, err)
+	genesis, err := oracle.L2Outputs(callOpts, start)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{0x01}, genesis)
+	nextTimestamp, err := oracle.NextTimestamp(callOpts)
+	require.NoError(t, err)
+	require.Equal(t, new(big.Int).Add(start, big.NewInt(10)), nextTimestamp)
+	blockNumber, err := oracle.ComputeL2BlockNumber(callOpts, nextTimestamp)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(105), blockNumber)
+
+	require.NoError(t, backend.AdjustTime(20*time.Second))
+	backend.Commit()
+	head, err := backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	t.Run("blockhash mismatch", func(t *testing.T) {
+		_, err := oracle.AppendL2Output(
+			opts, [32]byte{0x02}, nextTimestamp,
+			eth.BlockID{Hash: common.Hash{0x03}, Number: head.Number.Uint64()},
+		)
+		require.Error(t, err)
+		require.Contains(t, err.Error(),
+			"Blockhash does not match the hash at the expected height.")
+	})
+
+	// The output is appended on top of the canonical L1 block.
+	_, err = oracle.AppendL2Output(
+		opts, [32]byte{0x02}, nextTimestamp,
+		eth.BlockID{Hash: head.Hash(), Number: head.Number.Uint64()},
+	)
+	require.NoError(t, err)
+	backend.Commit()
+
+	latest, err := oracle.LatestBlockTimestamp(callOpts)
+	require.NoError(t, err)
+	require.Equal(t, nextTimestamp, latest)
+	output, err := oracle.L2Outputs(callOpts, nextTimestamp)
+	require.NoError(t, err)
+	require.Equal(t, [32]byte{0x02}, output)
+
+	contract, err := l2oo.NewL2OutputOracle(addr, backend)
+	require.NoError(t, err)
+	events, err := contract.FilterL2OutputAppended(
+		&bind.FilterOpts{Context: ctx}, nil, nil, nil,
+	)
+	require.NoError(t, err)
+	require.True(t, events.Next())
+	require.Equal(t, [32]byte{0x02}, events.Event.L2Output)
+	require.Equal(t, nextTimestamp, events.Event.L2timestamp)
+	require.False(t, events.Next())
+}
+
+func TestCheckOracleVersion(t *testing.T) {
+	require.NoError(t, CheckOracleVersion("1.0.0"))
+	require.NoError(t, CheckOracleVersion("1.10.3"))
+	require.Error(t, CheckOracleVersion("0.9.0"))
+	require.Error(t, CheckOracleVersion("2.0.0"))
+	require.Error(t, CheckOracleVersion("1.0"))
+	require.Error(t, CheckOracleVersion("1.0.0-beta"))
+	require.Error(t, CheckOracleVersion(""))
+}
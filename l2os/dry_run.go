@@ -0,0 +1,107 @@
+package l2os
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// errDryRunFailed signals that the simulated transaction would revert.
+var errDryRunFailed = errors.New("simulated tx would revert")
+
+// isSimulatedRevert returns true if the error of a call or gas estimation
+// signals that the simulated transaction reverts, as opposed to a failure to
+// simulate it.
+func isSimulatedRevert(err error) bool {
+	return strings.Contains(err.Error(), vm.ErrExecutionReverted.Error())
+}
+
+// DryRun crafts the transaction of the next block range, and simulates it
+// against the latest L1 state instead of publishing it. It logs the decoded
+// call data, the estimated gas and the outcome, and returns an error if the
+// transaction cannot be crafted or simulated. If the transaction would
+// revert, the error wraps errDryRunFailed.
+//
+// Only the next range is simulated, as later ranges build on the state
+// changes of the transactions before them.
+func (s *Service) DryRun() error {
+	name := s.cfg.Driver.Name()
+
+	ranges, err := s.cfg.Driver.GetBlockRanges(s.ctx, 1)
+	if err != nil {
+		log.Error(name+" dry run: unable to get block ranges", "err", err)
+		return err
+	}
+	if len(ranges) == 0 {
+		log.Info(name + " dry run: no updates")
+		return nil
+	}
+	r := ranges[0]
+
+	nonce64, err := s.cfg.L1Client.NonceAt(
+		s.ctx, s.cfg.Driver.WalletAddr(), nil,
+	)
+	if err != nil {
+		log.Error(name+" dry run: unable to get current nonce", "err", err)
+		return err
+	}
+
+	// Crafting the tx estimates its gas, which fails if the tx reverts.
+	tx, err := s.cfg.Driver.CraftTx(
+		s.ctx, r.Start, r.End, new(big.Int).SetUint64(nonce64),
+	)
+	if err != nil && isSimulatedRevert(err) {
+		log.Error(name+" dry run: tx would revert", "start", r.Start,
+			"end", r.End, "err", err)
+		return fmt.Errorf("%w: %v", errDryRunFailed, err)
+	} else if err != nil {
+		log.Error(name+" dry run: unable to craft tx", "start", r.Start,
+			"end", r.End, "err", err)
+		return err
+	}
+
+	decoded, err := s.cfg.Driver.DecodeTx(tx)
+	if err != nil {
+		log.Error(name+" dry run: unable to decode tx", "err", err)
+		return err
+	}
+	logCtx := []interface{}{"start", r.Start, "end", r.End,
+		"nonce", tx.Nonce(), "from", s.cfg.Driver.WalletAddr(),
+		"to", tx.To(), "gasLimit", tx.Gas()}
+	log.Info(name+" dry run: crafted tx", append(logCtx, decoded...)...)
+
+	// Simulate the tx with its gas limit, and estimate the gas it uses.
+	msg := ethereum.CallMsg{
+		From:  s.cfg.Driver.WalletAddr(),
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err = s.cfg.L1Client.CallContract(s.ctx, msg, nil)
+	if err != nil && isSimulatedRevert(err) {
+		log.Error(name+" dry run: tx would revert", "err", err)
+		return fmt.Errorf("%w: %v", errDryRunFailed, err)
+	} else if err != nil {
+		log.Error(name+" dry run: unable to simulate tx", "err", err)
+		return err
+	}
+	msg.Gas = 0
+	gas, err := s.cfg.L1Client.EstimateGas(s.ctx, msg)
+	if err != nil && isSimulatedRevert(err) {
+		log.Error(name+" dry run: tx would revert", "err", err)
+		return fmt.Errorf("%w: %v", errDryRunFailed, err)
+	} else if err != nil {
+		log.Error(name+" dry run: unable to estimate gas", "err", err)
+		return err
+	}
+
+	log.Info(name+" dry run: tx would succeed", "estimatedGas", gas,
+		"gasLimit", tx.Gas())
+	return nil
+}
@@ -0,0 +1,159 @@
+package l2os
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/bindings/l2oo"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// simulatedL1 is a simulated L1 chain, serving as the L1Client of a Service.
+type simulatedL1 struct {
+	*backends.SimulatedBackend
+}
+
+func (l *simulatedL1) BlockNumber(ctx context.Context) (uint64, error) {
+	return l.Blockchain().CurrentBlock().NumberU64(), nil
+}
+
+// oracleDriver crafts txs that append an output at the configured timestamp
+// to an L2OutputOracle.
+type oracleDriver struct {
+	oracle    *l2oo.L2OutputOracle
+	opts      *bind.TransactOpts
+	timestamp *big.Int
+	// gasLimit, if set, skips the gas estimation of crafted txs.
+	gasLimit uint64
+	// craftErr, if set, is returned when crafting a tx.
+	craftErr error
+}
+
+func (d *oracleDriver) Name() string {
+	return "test"
+}
+
+func (d *oracleDriver) WalletAddr() common.Address {
+	return d.opts.From
+}
+
+func (d *oracleDriver) GetBlockRanges(
+	ctx context.Context, max int) ([]drivers.BlockRange, error) {
+
+	return []drivers.BlockRange{{Start: big.NewInt(0), End: big.NewInt(1)}},
+		nil
+}
+
+func (d *oracleDriver) CraftTx(
+	ctx context.Context, start, end, nonce *big.Int,
+) (*types.Transaction, error) {
+
+	if d.craftErr != nil {
+		return nil, d.craftErr
+	}
+	opts := *d.opts
+	opts.Context = ctx
+	opts.Nonce = nonce
+	opts.GasLimit = d.gasLimit
+	opts.NoSend = true
+	return d.oracle.AppendL2Output(
+		&opts, [32]byte{0x02}, d.timestamp, [32]byte{}, new(big.Int),
+	)
+}
+
+func (d *oracleDriver) UpdateGasPrice(
+	ctx context.Context, tx *types.Transaction,
+	gasTipCap, gasFeeCap *big.Int,
+) (*types.Transaction, error) {
+
+	return nil, errors.New("not implemented")
+}
+
+func (d *oracleDriver) IsTxStale(
+	ctx context.Context, tx *types.Transaction) (bool, error) {
+
+	return false, nil
+}
+
+func (d *oracleDriver) DecodeTx(tx *types.Transaction) ([]interface{}, error) {
+	return []interface{}{"data", tx.Data()}, nil
+}
+
+func (d *oracleDriver) SendTransaction(
+	ctx context.Context, tx *types.Transaction) error {
+
+	return errors.New("not implemented")
+}
+
+func TestServiceDryRun(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	backend := backends.NewSimulatedBackend(core.GenesisAlloc{
+		opts.From: {Balance: big.NewInt(params.Ether)},
+	}, 15_000_000)
+	defer backend.Close()
+
+	_, _, oracle, err := l2oo.DeployL2OutputOracle(
+		opts, backend, big.NewInt(10), big.NewInt(2), [32]byte{0x01},
+		big.NewInt(0), opts.From,
+	)
+	require.NoError(t, err)
+	backend.Commit()
+	require.NoError(t, backend.AdjustTime(20*time.Second))
+	backend.Commit()
+	nextTimestamp, err := oracle.NextTimestamp(&bind.CallOpts{})
+	require.NoError(t, err)
+
+	dryRun := func(driver *oracleDriver) error {
+		service := newTestService(
+			t, &simulatedL1{backend}, driver, metrics.NewRegistry(), "test",
+		)
+		return service.DryRun()
+	}
+
+	t.Run("passing", func(t *testing.T) {
+		require.NoError(t, dryRun(&oracleDriver{
+			oracle: oracle, opts: opts, timestamp: nextTimestamp,
+		}))
+	})
+	t.Run("reverting gas estimation", func(t *testing.T) {
+		err := dryRun(&oracleDriver{
+			oracle: oracle, opts: opts, timestamp: big.NewInt(1),
+		})
+		require.ErrorIs(t, err, errDryRunFailed)
+		require.Contains(t, err.Error(),
+			"Timestamp not equal to next expected timestamp")
+	})
+	t.Run("reverting simulation", func(t *testing.T) {
+		err := dryRun(&oracleDriver{
+			oracle: oracle, opts: opts, timestamp: big.NewInt(1),
+			gasLimit: 100_000,
+		})
+		require.ErrorIs(t, err, errDryRunFailed)
+		require.Contains(t, err.Error(),
+			"Timestamp not equal to next expected timestamp")
+	})
+	t.Run("crafting failure", func(t *testing.T) {
+		craftErr := errors.New("rollup node unavailable")
+		err := dryRun(&oracleDriver{
+			oracle: oracle, opts: opts, timestamp: nextTimestamp,
+			craftErr: craftErr,
+		})
+		require.ErrorIs(t, err, craftErr)
+		require.False(t, errors.Is(err, errDryRunFailed))
+	})
+}
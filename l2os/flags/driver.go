@@ -0,0 +1,222 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/urfave/cli"
+)
+
+// DriverFlags are the flags of the config section of a single driver. The
+// flags of a section are prefixed with the label of the driver, e.g.
+// --l2output.mnemonic, such that every driver has its own wallet, transaction
+// and fee settings. In the config file, a section is a map under the label:
+//
+//	l2output:
+//	  mnemonic: ...
+//	  journal-path: ...
+type DriverFlags struct {
+	Label string
+
+	/* Required Flags */
+
+	NumConfirmations          cli.Uint64Flag
+	SafeAbortNonceTooLowCount cli.Uint64Flag
+	ResubmissionTimeout       cli.DurationFlag
+
+	/* Optional Flags */
+
+	Mnemonic             cli.StringFlag
+	HDPath               cli.StringFlag
+	Keystore             cli.StringFlag
+	KeystorePassword     cli.StringFlag
+	SignerEndpoint       cli.StringFlag
+	SignerAddress        cli.StringFlag
+	MaxPendingTxs        cli.Uint64Flag
+	MaxGasTipCap         cli.Uint64Flag
+	MaxGasFeeCap         cli.Uint64Flag
+	FeeBumpPercent       cli.Uint64Flag
+	FeeHistoryBlocks     cli.Uint64Flag
+	JournalPath          cli.StringFlag
+	DeferMaxBaseFee      cli.Uint64Flag
+	DeferDeadlinePercent cli.Uint64Flag
+	BalanceWarnThreshold cli.StringFlag
+	MinBalance           cli.StringFlag
+}
+
+// NewDriverFlags returns the flags of the config section of the driver with
+// the given label. The environment variables of the section are prefixed with
+// the upper case label, e.g. BATCH_SUBMITTER_L2OUTPUT_MNEMONIC.
+func NewDriverFlags(label string) DriverFlags {
+	name := func(name string) string {
+		return label + "." + name
+	}
+	envVar := func(name string) string {
+		return prefixEnvVar(strings.ToUpper(label) + "_" + name)
+	}
+	return DriverFlags{
+		Label: label,
+
+		NumConfirmations: cli.Uint64Flag{
+			Name: name("num-confirmations"),
+			Usage: "Number of confirmations which we will wait after " +
+				"appending a new batch",
+			EnvVar: envVar("NUM_CONFIRMATIONS"),
+		},
+		SafeAbortNonceTooLowCount: cli.Uint64Flag{
+			Name: name("safe-abort-nonce-too-low-count"),
+			Usage: "Number of ErrNonceTooLow observations required to " +
+				"give up on a tx at a particular nonce without receiving " +
+				"confirmation",
+			EnvVar: envVar("SAFE_ABORT_NONCE_TOO_LOW_COUNT"),
+		},
+		ResubmissionTimeout: cli.DurationFlag{
+			Name: name("resubmission-timeout"),
+			Usage: "Duration we will wait before resubmitting a " +
+				"transaction to L1",
+			EnvVar: envVar("RESUBMISSION_TIMEOUT"),
+		},
+
+		Mnemonic: cli.StringFlag{
+			Name: name("mnemonic"),
+			Usage: "The mnemonic used to derive the wallet of the driver. " +
+				"Prefer the keystore or remote signer, a leaked mnemonic " +
+				"leaks all derived keys",
+			EnvVar: envVar("MNEMONIC"),
+		},
+		HDPath: cli.StringFlag{
+			Name: name("hd-path"),
+			Usage: "The HD path used to derive the wallet from the " +
+				"mnemonic. The mnemonic flag must also be set.",
+			EnvVar: envVar("HD_PATH"),
+		},
+		Keystore: cli.StringFlag{
+			Name:   name("keystore"),
+			Usage:  "Encrypted JSON keystore file of the wallet",
+			EnvVar: envVar("KEYSTORE"),
+		},
+		KeystorePassword: cli.StringFlag{
+			Name:   name("keystore-password"),
+			Usage:  "File with the passphrase of the keystore",
+			EnvVar: envVar("KEYSTORE_PASSWORD"),
+		},
+		SignerEndpoint: cli.StringFlag{
+			Name: name("signer-endpoint"),
+			Usage: "JSON-RPC endpoint of a remote signer " +
+				"(eth_signTransaction) for the wallet",
+			EnvVar: envVar("SIGNER_ENDPOINT"),
+		},
+		SignerAddress: cli.StringFlag{
+			Name:   name("signer-address"),
+			Usage:  "Address of the wallet of the remote signer",
+			EnvVar: envVar("SIGNER_ADDRESS"),
+		},
+		MaxPendingTxs: cli.Uint64Flag{
+			Name: name("max-pending-txs"),
+			Usage: "The maximum number of transactions that are " +
+				"submitted concurrently when catching up",
+			Value:  10,
+			EnvVar: envVar("MAX_PENDING_TXS"),
+		},
+		MaxGasTipCap: cli.Uint64Flag{
+			Name: name("max-gas-tip-cap"),
+			Usage: "The maximum gas tip cap of submitted transactions, " +
+				"in gwei. 0 for no maximum",
+			EnvVar: envVar("MAX_GAS_TIP_CAP"),
+		},
+		MaxGasFeeCap: cli.Uint64Flag{
+			Name: name("max-gas-fee-cap"),
+			Usage: "The maximum gas fee cap of submitted transactions, " +
+				"in gwei. 0 for no maximum",
+			EnvVar: envVar("MAX_GAS_FEE_CAP"),
+		},
+		FeeBumpPercent: cli.Uint64Flag{
+			Name: name("fee-bump-percent"),
+			Usage: "The percentage by which the fees of a transaction " +
+				"are bumped on every resubmission, at least 10",
+			Value:  10,
+			EnvVar: envVar("FEE_BUMP_PERCENT"),
+		},
+		FeeHistoryBlocks: cli.Uint64Flag{
+			Name: name("fee-history-blocks"),
+			Usage: "The number of recent L1 blocks the base fee is " +
+				"sampled from to compute the gas fee cap",
+			Value:  10,
+			EnvVar: envVar("FEE_HISTORY_BLOCKS"),
+		},
+		JournalPath: cli.StringFlag{
+			Name: name("journal-path"),
+			Usage: "File that published transactions are journaled in " +
+				"until they confirm, to resume them after a restart",
+			EnvVar: envVar("JOURNAL_PATH"),
+		},
+		DeferMaxBaseFee: cli.Uint64Flag{
+			Name: name("defer-max-base-fee"),
+			Usage: "The L1 base fee, in gwei, above which submissions " +
+				"are deferred. 0 to never defer",
+			EnvVar: envVar("DEFER_MAX_BASE_FEE"),
+		},
+		DeferDeadlinePercent: cli.Uint64Flag{
+			Name: name("defer-deadline-percent"),
+			Usage: "The percentage of the submission interval after " +
+				"which a deferred submission is submitted regardless of " +
+				"the base fee",
+			Value:  50,
+			EnvVar: envVar("DEFER_DEADLINE_PERCENT"),
+		},
+		BalanceWarnThreshold: cli.StringFlag{
+			Name: name("balance-warn-threshold"),
+			Usage: "The wallet balance, in ether, below which a warning " +
+				"is logged",
+			EnvVar: envVar("BALANCE_WARN_THRESHOLD"),
+		},
+		MinBalance: cli.StringFlag{
+			Name: name("min-balance"),
+			Usage: "The wallet balance, in ether, below which " +
+				"submissions are paused",
+			EnvVar: envVar("MIN_BALANCE"),
+		},
+	}
+}
+
+// Required returns the flags that must be set if the section is configured.
+func (f DriverFlags) Required() []cli.Flag {
+	return []cli.Flag{
+		f.NumConfirmations,
+		f.SafeAbortNonceTooLowCount,
+		f.ResubmissionTimeout,
+	}
+}
+
+// Flags returns all flags of the section.
+func (f DriverFlags) Flags() []cli.Flag {
+	return append(f.Required(),
+		f.Mnemonic,
+		f.HDPath,
+		f.Keystore,
+		f.KeystorePassword,
+		f.SignerEndpoint,
+		f.SignerAddress,
+		f.MaxPendingTxs,
+		f.MaxGasTipCap,
+		f.MaxGasFeeCap,
+		f.FeeBumpPercent,
+		f.FeeHistoryBlocks,
+		f.JournalPath,
+		f.DeferMaxBaseFee,
+		f.DeferDeadlinePercent,
+		f.BalanceWarnThreshold,
+		f.MinBalance,
+	)
+}
+
+// IsSet returns whether any flag of the section is set on the command line,
+// in the environment or in the config file. Drivers only run if their section
+// is set.
+func (f DriverFlags) IsSet(ctx *cli.Context) bool {
+	for _, flag := range f.Flags() {
+		if ctx.GlobalIsSet(flag.GetName()) {
+			return true
+		}
+	}
+	return false
+}
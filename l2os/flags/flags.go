@@ -14,92 +14,124 @@ var (
 	/* Required Flags */
 
 	L1EthRpcFlag = cli.StringFlag{
-		Name:     "l1-eth-rpc",
-		Usage:    "HTTP provider URL for L1",
-		Required: true,
-		EnvVar:   "L1_ETH_RPC",
+		Name:   "l1-eth-rpc",
+		Usage:  "HTTP provider URL for L1",
+		EnvVar: "L1_ETH_RPC",
 	}
-	L2EthRpcFlag = cli.StringFlag{
-		Name:     "l2-eth-rpc",
-		Usage:    "HTTP provider URL for L2",
-		Required: true,
-		EnvVar:   "L2_ETH_RPC",
+	RollupRpcFlag = cli.StringFlag{
+		Name:   "rollup-rpc",
+		Usage:  "HTTP provider URL for the rollup node",
+		EnvVar: "ROLLUP_RPC",
 	}
 	L2OOAddressFlag = cli.StringFlag{
-		Name:     "l2oo-address",
-		Usage:    "Address of the L2OutputOracle contract",
-		Required: true,
-		EnvVar:   "L2OO_ADDRESS",
+		Name:   "l2oo-address",
+		Usage:  "Address of the L2OutputOracle contract",
+		EnvVar: "L2OO_ADDRESS",
 	}
 	PollIntervalFlag = cli.DurationFlag{
 		Name: "poll-interval",
 		Usage: "Delay between querying L2 for more transactions and " +
 			"creating a new batch",
-		Required: true,
-		EnvVar:   prefixEnvVar("POLL_INTERVAL"),
+		EnvVar: prefixEnvVar("POLL_INTERVAL"),
 	}
-	NumConfirmationsFlag = cli.Uint64Flag{
-		Name: "num-confirmations",
-		Usage: "Number of confirmations which we will wait after " +
-			"appending a new batch",
-		Required: true,
-		EnvVar:   prefixEnvVar("NUM_CONFIRMATIONS"),
-	}
-	SafeAbortNonceTooLowCountFlag = cli.Uint64Flag{
-		Name: "safe-abort-nonce-too-low-count",
-		Usage: "Number of ErrNonceTooLow observations required to " +
-			"give up on a tx at a particular nonce without receiving " +
-			"confirmation",
-		Required: true,
-		EnvVar:   prefixEnvVar("SAFE_ABORT_NONCE_TOO_LOW_COUNT"),
-	}
-	ResubmissionTimeoutFlag = cli.DurationFlag{
-		Name: "resubmission-timeout",
-		Usage: "Duration we will wait before resubmitting a " +
-			"transaction to L1",
-		Required: true,
-		EnvVar:   prefixEnvVar("RESUBMISSION_TIMEOUT"),
-	}
-	MnemonicFlag = cli.StringFlag{
-		Name: "mnemonic",
-		Usage: "The mnemonic used to derive the wallets for either the " +
-			"sequencer or the l2output",
-		Required: true,
-		EnvVar:   prefixEnvVar("MNEMONIC"),
-	}
-	L2OutputHDPathFlag = cli.StringFlag{
-		Name: "l2-output-hd-path",
-		Usage: "The HD path used to derive the l2output wallet from the " +
-			"mnemonic. The mnemonic flag must also be set.",
-		Required: true,
-		EnvVar:   prefixEnvVar("L2_OUTPUT_HD_PATH"),
-	}
-
 	/* Optional Flags */
 
+	ConfigFlag = cli.StringFlag{
+		Name: "config",
+		Usage: "YAML config file of flag values, overridden by flags and " +
+			"environment variables",
+		EnvVar: prefixEnvVar("CONFIG"),
+	}
+	L2OOMockFlag = cli.BoolFlag{
+		Name: "l2-output-oracle.mock",
+		Usage: "Bind the L2 output oracle as the MockL2OutputOracle, " +
+			"which has no version and no reorg protection",
+		EnvVar: prefixEnvVar("L2_OUTPUT_ORACLE_MOCK"),
+	}
 	LogLevelFlag = cli.StringFlag{
 		Name:   "log-level",
 		Usage:  "The lowest log level that will be output",
 		Value:  "info",
 		EnvVar: prefixEnvVar("LOG_LEVEL"),
 	}
+	WatcherFlag = cli.BoolFlag{
+		Name: "watcher",
+		Usage: "Check previously proposed L2 outputs against the outputs " +
+			"computed by the rollup node, and report mismatches",
+		EnvVar: prefixEnvVar("WATCHER"),
+	}
+	DryRunFlag = cli.BoolFlag{
+		Name: "dry-run",
+		Usage: "Craft and simulate the next transaction without " +
+			"publishing it, and exit with an error if it would revert",
+		EnvVar: prefixEnvVar("DRY_RUN"),
+	}
+	MetricsEnabledFlag = cli.BoolFlag{
+		Name:   "metrics-enabled",
+		Usage:  "Serve metrics in the Prometheus format",
+		EnvVar: prefixEnvVar("METRICS_ENABLED"),
+	}
+	MetricsAddrFlag = cli.StringFlag{
+		Name:   "metrics-addr",
+		Usage:  "Listening address of the metrics server",
+		Value:  "0.0.0.0",
+		EnvVar: prefixEnvVar("METRICS_ADDR"),
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:   "metrics-port",
+		Usage:  "Listening port of the metrics server",
+		Value:  7300,
+		EnvVar: prefixEnvVar("METRICS_PORT"),
+	}
 )
 
-var requiredFlags = []cli.Flag{
+// RequiredFlags must be set on the command line, in the environment or in the
+// config file. They are checked once the config file is loaded.
+var RequiredFlags = []cli.Flag{
 	L1EthRpcFlag,
-	L2EthRpcFlag,
+	RollupRpcFlag,
 	L2OOAddressFlag,
 	PollIntervalFlag,
-	NumConfirmationsFlag,
-	SafeAbortNonceTooLowCountFlag,
-	ResubmissionTimeoutFlag,
-	MnemonicFlag,
-	L2OutputHDPathFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	ConfigFlag,
+	L2OOMockFlag,
 	LogLevelFlag,
+	WatcherFlag,
+	DryRunFlag,
+	MetricsEnabledFlag,
+	MetricsAddrFlag,
+	MetricsPortFlag,
+}
+
+// DriverSections are the flag sections of the drivers that l2os can run, in
+// the order they are started in.
+var DriverSections = []DriverFlags{
+	NewDriverFlags("l2output"),
 }
 
+// SecretFlags are redacted when the config is dumped: the mnemonic, and the
+// passphrase file of the keystore, of every driver.
+var SecretFlags = driverSecretFlags()
+
 // Flags contains the list of configuration options available to the binary.
-var Flags = append(requiredFlags, optionalFlags...)
+var Flags = allFlags()
+
+func driverSecretFlags() []cli.Flag {
+	var secretFlags []cli.Flag
+	for _, section := range DriverSections {
+		secretFlags = append(secretFlags, section.Mnemonic,
+			section.KeystorePassword)
+	}
+	return secretFlags
+}
+
+func allFlags() []cli.Flag {
+	flags := append([]cli.Flag{}, RequiredFlags...)
+	flags = append(flags, optionalFlags...)
+	for _, section := range DriverSections {
+		flags = append(flags, section.Flags()...)
+	}
+	return flags
+}
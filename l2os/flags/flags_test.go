@@ -7,20 +7,11 @@ import (
 	"github.com/urfave/cli"
 )
 
-// TestRequiredFlagsSetRequired asserts that all flags deemed required properly
-// have the Required field set to true.
-func TestRequiredFlagsSetRequired(t *testing.T) {
-	for _, flag := range requiredFlags {
-		reqFlag, ok := flag.(cli.RequiredFlag)
-		require.True(t, ok)
-		require.True(t, reqFlag.IsRequired())
-	}
-}
-
-// TestOptionalFlagsDontSetRequired asserts that all flags deemed optional set
-// the Required field to false.
-func TestOptionalFlagsDontSetRequired(t *testing.T) {
-	for _, flag := range optionalFlags {
+// TestFlagsDontSetRequired asserts that no flag sets the Required field.
+// Required flags may be set in the config file, which is only loaded after
+// the cli checks the Required field.
+func TestFlagsDontSetRequired(t *testing.T) {
+	for _, flag := range Flags {
 		reqFlag, ok := flag.(cli.RequiredFlag)
 		require.True(t, ok)
 		require.False(t, reqFlag.IsRequired())
@@ -3,17 +3,27 @@ package l2os
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers/l2output"
 	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/watcher"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/client"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	hdwallet "github.com/miguelmota/go-ethereum-hdwallet"
 	"github.com/urfave/cli"
 )
@@ -30,7 +40,11 @@ const (
 // GitVersion, to be captured and used once the function is executed.
 func Main(version string) func(ctx *cli.Context) error {
 	return func(ctx *cli.Context) error {
-		cfg := NewConfig(ctx)
+		cfg, err := NewConfig(ctx)
+		if err != nil {
+			log.Error("Unable to create the config", "error", err)
+			return err
+		}
 
 		log.Info("Initializing L2 Output Submitter")
 
@@ -40,6 +54,11 @@ func Main(version string) func(ctx *cli.Context) error {
 			return err
 		}
 
+		if cfg.DryRun {
+			log.Info("Running L2 Output Submitter dry run")
+			return l2OutputSubmitter.DryRun()
+		}
+
 		log.Info("Starting L2 Output Submitter")
 
 		if err := l2OutputSubmitter.Start(); err != nil {
@@ -63,11 +82,14 @@ func Main(version string) func(ctx *cli.Context) error {
 	}
 }
 
-// L2OutputSubmitter encapsulates a service responsible for submitting
-// L2Outputs to the L2OutputOracle contract.
+// L2OutputSubmitter encapsulates the services responsible for submitting
+// L2Outputs to the L2OutputOracle contract, and any other drivers that run in
+// the same process.
 type L2OutputSubmitter struct {
-	ctx             context.Context
-	l2OutputService *Service
+	ctx           context.Context
+	services      []*Service
+	watcher       *watcher.Watcher
+	metricsServer *metricsServer
 }
 
 // NewL2OutputSubmitter initializes the L2OutputSubmitter, gathering any resources
@@ -85,34 +107,37 @@ func NewL2OutputSubmitter(cfg Config, gitVersion string) (*L2OutputSubmitter, er
 
 	log.Root().SetHandler(log.LvlFilterHandler(logLevel, logHandler))
 
-	// Parse l2output wallet private key and L2OO contract address.
-	wallet, err := hdwallet.NewFromMnemonic(cfg.Mnemonic)
+	// Parse the L2OO contract address, and check the driver config sections.
+	l2ooAddress, err := parseAddress(cfg.L2OOAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	l2OutputPrivKey, err := wallet.PrivateKey(accounts.Account{
-		URL: accounts.URL{
-			Path: cfg.L2OutputHDPath,
-		},
-	})
-	if err != nil {
-		return nil, err
+	sections := cfg.DriverSections
+	for _, section := range sections {
+		if err := section.Config.Check(); err != nil {
+			return nil, fmt.Errorf("%s: %w", section.Label, err)
+		}
 	}
 
-	l2ooAddress, err := parseAddress(cfg.L2OOAddress)
+	// Connect to L1 and rollup node providers. Perform these last since they
+	// are the most expensive.
+	l1Client, err := dialEthClientWithTimeout(ctx, cfg.L1EthRpc)
 	if err != nil {
 		return nil, err
 	}
 
-	// Connect to L1 and L2 providers. Perform these last since they are the
-	// most expensive.
-	l1Client, err := dialEthClientWithTimeout(ctx, cfg.L1EthRpc)
+	rollupRPCClient, err := dialRPCClientWithTimeout(ctx, cfg.RollupRpc)
 	if err != nil {
 		return nil, err
 	}
+	rollupClient := client.NewRollupClient(rollupRPCClient)
 
-	l2Client, err := dialEthClientWithTimeout(ctx, cfg.L2EthRpc)
+	// Bind the oracle, checking that its version is supported, unless the
+	// mock is explicitly configured.
+	oracle, err := l2output.NewOutputOracle(
+		ctx, l2ooAddress, l1Client, cfg.L2OOMock,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -122,45 +147,235 @@ func NewL2OutputSubmitter(cfg Config, gitVersion string) (*L2OutputSubmitter, er
 		return nil, err
 	}
 
-	txManagerConfig := txmgr.Config{
-		ResubmissionTimeout:       cfg.ResubmissionTimeout,
-		ReceiptQueryInterval:      time.Second,
-		NumConfirmations:          cfg.NumConfirmations,
-		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+	registry := metrics.NewRegistry()
+
+	// The constructors of the drivers, by the label of their config section.
+	newDrivers := map[string]func(s signer.Signer) (Driver, error){
+		"l2output": func(s signer.Signer) (Driver, error) {
+			return l2output.NewDriver(l2output.Config{
+				Name:         "L2Output Submitter",
+				L1Client:     l1Client,
+				RollupClient: rollupClient,
+				L2OOAddr:     l2ooAddress,
+				Oracle:       oracle,
+				ChainID:      chainID,
+				Signer:       s,
+			})
+		},
 	}
 
-	l2OutputDriver, err := l2output.NewDriver(l2output.Config{
-		Name:     "L2Output Submitter",
-		L1Client: l1Client,
-		L2Client: l2Client,
-		L2OOAddr: l2ooAddress,
-		ChainID:  chainID,
-		PrivKey:  l2OutputPrivKey,
-	})
+	var serviceConfigs []driverServiceConfig
+	for _, section := range sections {
+		newDriver, ok := newDrivers[section.Label]
+		if !ok {
+			return nil, fmt.Errorf("no driver registered for config "+
+				"section %q", section.Label)
+		}
+		serviceConfigs = append(serviceConfigs, driverServiceConfig{
+			Label:        section.Label,
+			Config:       section.Config,
+			PollInterval: cfg.PollInterval,
+			L1Client:     l1Client,
+			Registry:     registry,
+			NewDriver:    newDriver,
+		})
+	}
+	services, err := newDriverServices(ctx, serviceConfigs)
 	if err != nil {
 		return nil, err
 	}
 
-	l2OutputService := NewService(ServiceConfig{
-		Context:         ctx,
-		Driver:          l2OutputDriver,
-		PollInterval:    cfg.PollInterval,
-		L1Client:        l1Client,
-		TxManagerConfig: txManagerConfig,
-	})
+	var outputWatcher *watcher.Watcher
+	if cfg.Watcher {
+		outputWatcher = watcher.NewWatcher(ctx, watcher.Config{
+			Name:         "L2Output Watcher",
+			Oracle:       oracle,
+			RollupClient: rollupClient,
+			PollInterval: cfg.PollInterval,
+			Registry:     registry,
+		})
+	}
+
+	var metricsSrv *metricsServer
+	if cfg.MetricsEnabled {
+		wallets := make(map[string]*balance.Monitor, len(services))
+		for i, service := range services {
+			wallets[serviceConfigs[i].Label] = service.Balance()
+		}
+		metricsSrv = newMetricsServer(
+			cfg.MetricsAddr, cfg.MetricsPort, registry,
+			balance.HealthHandler(wallets),
+		)
+	}
 
 	return &L2OutputSubmitter{
-		ctx:             ctx,
-		l2OutputService: l2OutputService,
+		ctx:           ctx,
+		services:      services,
+		watcher:       outputWatcher,
+		metricsServer: metricsSrv,
 	}, nil
 }
 
 func (l *L2OutputSubmitter) Start() error {
-	return l.l2OutputService.Start()
+	if l.metricsServer != nil {
+		if err := l.metricsServer.Start(); err != nil {
+			return err
+		}
+	}
+	if l.watcher != nil {
+		if err := l.watcher.Start(); err != nil {
+			return err
+		}
+	}
+	for i, service := range l.services {
+		if err := service.Start(); err != nil {
+			stopServices(l.services[:i])
+			return err
+		}
+	}
+	return nil
 }
 
 func (l *L2OutputSubmitter) Stop() {
-	_ = l.l2OutputService.Stop()
+	stopServices(l.services)
+	if l.watcher != nil {
+		_ = l.watcher.Stop()
+	}
+	if l.metricsServer != nil {
+		l.metricsServer.Stop()
+	}
+}
+
+// DryRun simulates the next transaction of every service without publishing
+// it, and returns an error if any of them fails.
+func (l *L2OutputSubmitter) DryRun() error {
+	var failed int
+	for _, service := range l.services {
+		if err := service.DryRun(); err != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("dry run failed for %d of %d drivers", failed,
+			len(l.services))
+	}
+	return nil
+}
+
+// stopServices stops the services concurrently, and waits until all of them
+// have shut down, such that a service waiting on a tx does not hold up the
+// shutdown of the others.
+func stopServices(services []*Service) {
+	var wg sync.WaitGroup
+	for _, service := range services {
+		wg.Add(1)
+		go func(service *Service) {
+			defer wg.Done()
+			_ = service.Stop()
+		}(service)
+	}
+	wg.Wait()
+}
+
+// driverServiceConfig houses the parameters of the Service of a single driver.
+type driverServiceConfig struct {
+	// Label identifies the driver in errors and metrics.
+	Label string
+
+	// Config is the config section of the driver.
+	Config DriverConfig
+
+	PollInterval time.Duration
+	L1Client     L1Client
+	Registry     metrics.Registry
+
+	// NewDriver creates the driver, signing with the wallet of its config
+	// section.
+	NewDriver func(s signer.Signer) (Driver, error)
+}
+
+// newDriverServices creates the Services of the drivers. The labels of the
+// drivers must be unique, as they distinguish their metrics and wallets.
+func newDriverServices(
+	ctx context.Context, cfgs []driverServiceConfig) ([]*Service, error) {
+
+	labels := make(map[string]struct{}, len(cfgs))
+	services := make([]*Service, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if _, ok := labels[cfg.Label]; ok {
+			return nil, fmt.Errorf("duplicate driver %q", cfg.Label)
+		}
+		labels[cfg.Label] = struct{}{}
+
+		service, err := newDriverService(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, service)
+	}
+	return services, nil
+}
+
+// newDriverService creates the Service of a driver, with its own wallet, tx
+// manager, journal and metrics.
+func newDriverService(
+	ctx context.Context, cfg driverServiceConfig) (*Service, error) {
+
+	signerConfig, err := newSignerConfig(cfg.Config)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Label, err)
+	}
+
+	driverSigner, err := signer.NewSigner(ctx, signerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Label, err)
+	}
+
+	var journal *txmgr.Journal
+	if cfg.Config.JournalPath != "" {
+		journal, err = txmgr.OpenJournal(cfg.Config.JournalPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", cfg.Label, err)
+		}
+	}
+
+	balanceConfig, err := cfg.Config.BalanceConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Label, err)
+	}
+
+	driver, err := cfg.NewDriver(driverSigner)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cfg.Label, err)
+	}
+
+	return NewService(ServiceConfig{
+		Context:      ctx,
+		Driver:       driver,
+		PollInterval: cfg.PollInterval,
+		L1Client:     cfg.L1Client,
+		TxManagerConfig: txmgr.Config{
+			ResubmissionTimeout:       cfg.Config.ResubmissionTimeout,
+			ReceiptQueryInterval:      time.Second,
+			NumConfirmations:          cfg.Config.NumConfirmations,
+			SafeAbortNonceTooLowCount: cfg.Config.SafeAbortNonceTooLowCount,
+			Journal:                   journal,
+		},
+		FeePolicyConfig: txmgr.FeePolicyConfig{
+			FeeHistoryBlocks: cfg.Config.FeeHistoryBlocks,
+			MaxGasTipCap:     gweiToWei(cfg.Config.MaxGasTipCap),
+			MaxGasFeeCap:     gweiToWei(cfg.Config.MaxGasFeeCap),
+			BumpPercent:      cfg.Config.FeeBumpPercent,
+		},
+		MaxPendingTxs: cfg.Config.MaxPendingTxs,
+		ScheduleConfig: ScheduleConfig{
+			MaxBaseFee:      gweiToWei(cfg.Config.DeferMaxBaseFee),
+			DeadlinePercent: cfg.Config.DeferDeadlinePercent,
+		},
+		BalanceConfig: balanceConfig,
+		Registry:      cfg.Registry,
+		MetricsLabel:  cfg.Label,
+	}), nil
 }
 
 // dialEthClientWithTimeout attempts to dial the L1 provider using the provided
@@ -175,6 +390,63 @@ func dialEthClientWithTimeout(ctx context.Context, url string) (
 	return ethclient.DialContext(ctxt, url)
 }
 
+// dialRPCClientWithTimeout attempts to dial the rollup node provider using the provided
+// URL. If the dial doesn't complete within defaultDialTimeout seconds, this
+// method will return an error.
+func dialRPCClientWithTimeout(ctx context.Context, url string) (
+	*rpc.Client, error) {
+
+	ctxt, cancel := context.WithTimeout(ctx, defaultDialTimeout)
+	defer cancel()
+
+	return rpc.DialContext(ctxt, url)
+}
+
+// newSignerConfig selects the signer of the wallet of a driver: a key derived
+// from the mnemonic, an encrypted keystore, or a remote signer.
+func newSignerConfig(cfg DriverConfig) (*signer.Config, error) {
+	var signerConfig signer.Config
+	if cfg.Mnemonic != "" {
+		wallet, err := hdwallet.NewFromMnemonic(cfg.Mnemonic)
+		if err != nil {
+			return nil, err
+		}
+		privKey, err := wallet.PrivateKey(accounts.Account{
+			URL: accounts.URL{
+				Path: cfg.HDPath,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		signerConfig.PrivateKey = privKey
+	}
+	if cfg.Keystore != "" {
+		signerConfig.KeystorePath = cfg.Keystore
+		if cfg.KeystorePassword != "" {
+			password, err := os.ReadFile(cfg.KeystorePassword)
+			if err != nil {
+				return nil, err
+			}
+			signerConfig.KeystorePassword = strings.TrimRight(
+				string(password), "\r\n",
+			)
+		}
+	}
+	if cfg.SignerEndpoint != "" {
+		signerAddress, err := parseAddress(cfg.SignerAddress)
+		if err != nil {
+			return nil, err
+		}
+		signerConfig.Endpoint = cfg.SignerEndpoint
+		signerConfig.Address = signerAddress
+	}
+	if err := signerConfig.Check(); err != nil {
+		return nil, err
+	}
+	return &signerConfig, nil
+}
+
 // parseAddress parses an ETH addres from a hex string. This method will fail if
 // the address is not a valid hexidecimal address.
 func parseAddress(address string) (common.Address, error) {
@@ -183,3 +455,10 @@ func parseAddress(address string) (common.Address, error) {
 	}
 	return common.Address{}, fmt.Errorf("invalid address: %v", address)
 }
+
+// gweiToWei converts an amount in gwei to wei.
+func gweiToWei(gwei uint64) *big.Int {
+	return new(big.Int).Mul(
+		new(big.Int).SetUint64(gwei), big.NewInt(params.GWei),
+	)
+}
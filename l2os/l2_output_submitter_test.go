@@ -0,0 +1,155 @@
+package l2os
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "test test test test test test test test test test " +
+	"test junk"
+
+func testDriverConfig(hdPath string) DriverConfig {
+	return DriverConfig{
+		NumConfirmations:          1,
+		SafeAbortNonceTooLowCount: 3,
+		ResubmissionTimeout:       100 * time.Millisecond,
+		Mnemonic:                  testMnemonic,
+		HDPath:                    hdPath,
+		MaxPendingTxs:             1,
+		FeeBumpPercent:            10,
+		FeeHistoryBlocks:          1,
+	}
+}
+
+func sender(t *testing.T, tx *types.Transaction) common.Address {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	require.NoError(t, err)
+	return from
+}
+
+// TestDriverServices asserts that the services of multiple drivers run side
+// by side, with their own wallets, tx managers and metrics.
+func TestDriverServices(t *testing.T) {
+	l1 := newFakeL1()
+	registry := metrics.NewRegistry()
+	drivers := make(map[string]*fakeDriver)
+	var cfgs []driverServiceConfig
+	for label, hdPath := range map[string]string{
+		"l2output": "m/44'/60'/0'/0/1",
+		"other":    "m/44'/60'/0'/0/2",
+	} {
+		label := label
+		cfgs = append(cfgs, driverServiceConfig{
+			Label:        label,
+			Config:       testDriverConfig(hdPath),
+			PollInterval: 10 * time.Millisecond,
+			L1Client:     l1,
+			Registry:     registry,
+			NewDriver: func(s signer.Signer) (Driver, error) {
+				driver := newFakeDriverWithSigner(l1, s, 1)
+				driver.name = label
+				drivers[label] = driver
+				return driver, nil
+			},
+		})
+	}
+
+	services, err := newDriverServices(context.Background(), cfgs)
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	require.NotEqual(t, services[0].txMgr, services[1].txMgr)
+	wallet0 := drivers[cfgs[0].Label].WalletAddr()
+	wallet1 := drivers[cfgs[1].Label].WalletAddr()
+	require.NotEqual(t, wallet0, wallet1)
+
+	for _, service := range services {
+		require.NoError(t, service.Start())
+	}
+	defer stopServices(services)
+
+	// Both drivers publish their tx with their own wallet at nonce 0.
+	waitFor(t, func() bool {
+		return l1.pending(wallet0, 0) != nil && l1.pending(wallet1, 0) != nil
+	})
+	require.Equal(t, wallet0, sender(t, l1.pending(wallet0, 0)))
+	require.Equal(t, wallet1, sender(t, l1.pending(wallet1, 0)))
+
+	for _, label := range []string{"l2output", "other"} {
+		gauge, ok := registry.Get(
+			"l2os/" + label + "/wallet/balance_gwei",
+		).(metrics.Gauge)
+		require.True(t, ok, label)
+		require.Equal(t, int64(params.Ether/params.GWei), gauge.Value())
+	}
+
+	t.Run("duplicate label", func(t *testing.T) {
+		_, err := newDriverServices(context.Background(),
+			[]driverServiceConfig{cfgs[0], cfgs[0]})
+		require.Error(t, err)
+	})
+}
+
+// TestStopServicesConcurrently asserts that stopServices stops all services
+// at once, such that a service that is slow to stop does not hold up the
+// shutdown of the others.
+func TestStopServicesConcurrently(t *testing.T) {
+	const n = 3
+	l1 := newFakeL1()
+
+	// Every service is stuck sending its tx until it is stopped, and then
+	// waits until all services are stopping before it returns.
+	var sending, stopping sync.WaitGroup
+	sending.Add(n)
+	stopping.Add(n)
+	allStopping := make(chan struct{})
+	go func() {
+		stopping.Wait()
+		close(allStopping)
+	}()
+	// The hook of a service may be called again on resubmission.
+	newSendHook := func() func(ctx context.Context) {
+		var sendingOnce, stoppingOnce sync.Once
+		return func(ctx context.Context) {
+			sendingOnce.Do(sending.Done)
+			<-ctx.Done()
+			stoppingOnce.Do(stopping.Done)
+			select {
+			case <-allStopping:
+			case <-time.After(5 * time.Second):
+				t.Error("services are not stopped concurrently")
+			}
+		}
+	}
+
+	var services []*Service
+	for i := 0; i < n; i++ {
+		driver := newFakeDriver(t, l1, 1)
+		driver.sendHook = newSendHook()
+		service := newTestService(
+			t, l1, driver, metrics.NewRegistry(), "test",
+		)
+		require.NoError(t, service.Start())
+		services = append(services, service)
+	}
+	sending.Wait()
+
+	stopped := make(chan struct{})
+	go func() {
+		stopServices(services)
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(10 * time.Second):
+		t.Fatal("services did not stop")
+	}
+}
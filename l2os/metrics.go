@@ -0,0 +1,57 @@
+package l2os
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+)
+
+// metricsServer serves the metrics of the registry in the Prometheus format,
+// and the health of the submitter.
+type metricsServer struct {
+	endpoint   string
+	registry   metrics.Registry
+	health     http.Handler
+	httpServer *http.Server
+}
+
+func newMetricsServer(
+	addr string, port int, registry metrics.Registry,
+	health http.Handler) *metricsServer {
+
+	return &metricsServer{
+		endpoint: net.JoinHostPort(addr, strconv.Itoa(port)),
+		registry: registry,
+		health:   health,
+	}
+}
+
+func (s *metricsServer) Start() error {
+	listener, err := net.Listen("tcp", s.endpoint)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", prometheus.Handler(s.registry))
+	mux.Handle("/health", s.health)
+	s.httpServer = &http.Server{Handler: mux}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Error("Metrics server failed", "err", err)
+		}
+	}()
+	log.Info("Metrics server started", "endpoint", listener.Addr())
+	return nil
+}
+
+func (s *metricsServer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = s.httpServer.Shutdown(ctx)
+}
@@ -0,0 +1,93 @@
+package l2os
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// ScheduleConfig houses parameters for deferring submissions while L1 is
+// congested.
+type ScheduleConfig struct {
+	// MaxBaseFee is the L1 base fee, in wei, above which submissions are
+	// deferred. If nil or zero, submissions are never deferred.
+	MaxBaseFee *big.Int
+
+	// DeadlinePercent is the percentage of the submission interval, counted
+	// from the due timestamp of the oldest pending range, after which the
+	// range is submitted regardless of the base fee.
+	DeadlinePercent uint64
+}
+
+// scheduler decides whether the pending block ranges are submitted now, or
+// deferred until the L1 base fee drops below the threshold.
+type scheduler struct {
+	name string
+	cfg  ScheduleConfig
+
+	deferred        metrics.Counter
+	deadlineReached metrics.Counter
+	baseFee         metrics.Gauge
+}
+
+func newScheduler(
+	name string, cfg ScheduleConfig, registry metrics.Registry) *scheduler {
+
+	baseFee := new(metrics.StandardGauge)
+	_ = registry.Register("schedule/base_fee", baseFee)
+
+	return &scheduler{
+		name:            name,
+		cfg:             cfg,
+		deferred:        metrics.NewRegisteredCounterForced("schedule/deferred", registry),
+		deadlineReached: metrics.NewRegisteredCounterForced("schedule/deadline_reached", registry),
+		baseFee:         baseFee,
+	}
+}
+
+// enabled returns whether submissions may be deferred at all.
+func (s *scheduler) enabled() bool {
+	return s.cfg.MaxBaseFee != nil && s.cfg.MaxBaseFee.Sign() > 0
+}
+
+// deadline returns the L1 timestamp after which the range must be submitted.
+func (s *scheduler) deadline(r drivers.BlockRange) uint64 {
+	return r.DueTimestamp + r.Interval*s.cfg.DeadlinePercent/100
+}
+
+// shouldDefer returns whether the submission of the given ranges is deferred,
+// given the latest L1 header. Only the oldest range is considered, as later
+// ranges cannot be included before it.
+func (s *scheduler) shouldDefer(
+	header *types.Header, ranges []drivers.BlockRange) bool {
+
+	if len(ranges) == 0 || header.BaseFee == nil {
+		return false
+	}
+	s.baseFee.Update(header.BaseFee.Int64())
+
+	if header.BaseFee.Cmp(s.cfg.MaxBaseFee) <= 0 {
+		log.Info(s.name+" base fee below threshold, submitting",
+			"baseFee", header.BaseFee, "maxBaseFee", s.cfg.MaxBaseFee)
+		return false
+	}
+
+	deadline := s.deadline(ranges[0])
+	if header.Time >= deadline {
+		log.Warn(s.name+" base fee above threshold, but deadline "+
+			"reached, submitting", "baseFee", header.BaseFee,
+			"maxBaseFee", s.cfg.MaxBaseFee, "deadline", deadline,
+			"timestamp", header.Time)
+		s.deadlineReached.Inc(1)
+		return false
+	}
+
+	log.Info(s.name+" base fee above threshold, deferring submission",
+		"baseFee", header.BaseFee, "maxBaseFee", s.cfg.MaxBaseFee,
+		"deadline", deadline, "timestamp", header.Time)
+	s.deferred.Inc(1)
+	return true
+}
@@ -0,0 +1,41 @@
+package l2os
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerShouldDefer(t *testing.T) {
+	s := newScheduler("TEST", ScheduleConfig{
+		MaxBaseFee:      big.NewInt(100),
+		DeadlinePercent: 50,
+	}, metrics.NewRegistry())
+	require.True(t, s.enabled())
+
+	ranges := []drivers.BlockRange{
+		{DueTimestamp: 1000, Interval: 60},
+		{DueTimestamp: 1060, Interval: 60},
+	}
+	header := func(baseFee int64, time uint64) *types.Header {
+		return &types.Header{BaseFee: big.NewInt(baseFee), Time: time}
+	}
+
+	require.False(t, s.shouldDefer(header(100, 1000), ranges))
+	require.True(t, s.shouldDefer(header(101, 1000), ranges))
+	require.True(t, s.shouldDefer(header(101, 1029), ranges))
+	// The deadline of the oldest range is reached.
+	require.False(t, s.shouldDefer(header(101, 1030), ranges))
+	require.False(t, s.shouldDefer(header(101, 1030), nil))
+
+	require.Equal(t, int64(2), s.deferred.Count())
+	require.Equal(t, int64(1), s.deadlineReached.Count())
+	require.Equal(t, int64(101), s.baseFee.Value())
+
+	disabled := newScheduler("TEST", ScheduleConfig{}, metrics.NewRegistry())
+	require.False(t, disabled.enabled())
+}
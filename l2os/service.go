@@ -2,15 +2,19 @@ package l2os
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"sync"
 	"time"
 
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
 	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 )
 
 // Driver is an interface for creating and submitting transactions for a
@@ -22,10 +26,12 @@ type Driver interface {
 	// WalletAddr is the wallet address used to pay for transaction fees.
 	WalletAddr() common.Address
 
-	// GetBlockRange returns the start and end L2 block heights that need to be
-	// processed. Note that the end value is *exclusive*, therefore if the
-	// returned values are identical nothing needs to be processed.
-	GetBlockRange(ctx context.Context) (*big.Int, *big.Int, error)
+	// GetBlockRanges returns the consecutive ranges of L2 block heights that
+	// need to be processed, in order, with at most max ranges. Every range is
+	// processed by a single transaction. Note that the end values are
+	// *exclusive*, and if no ranges are returned nothing needs to be
+	// processed.
+	GetBlockRanges(ctx context.Context, max int) ([]drivers.BlockRange, error)
 
 	// CraftTx transforms the L2 blocks between start and end into a transaction
 	// using the given nonce.
@@ -37,25 +43,74 @@ type Driver interface {
 	) (*types.Transaction, error)
 
 	// UpdateGasPrice signs an otherwise identical txn to the one provided but
-	// with updated gas prices sampled from the existing network conditions.
+	// with the given gas tip cap and gas fee cap.
 	//
 	// NOTE: Thie method SHOULD NOT publish the resulting transaction.
 	UpdateGasPrice(
 		ctx context.Context,
 		tx *types.Transaction,
+		gasTipCap, gasFeeCap *big.Int,
 	) (*types.Transaction, error)
 
+	// IsTxStale returns whether the tx can no longer be included as crafted,
+	// e.g. because the L1 block it references was reorged out. Stale txs are
+	// not resubmitted, but crafted again, replacing the stale tx with bumped
+	// fees.
+	IsTxStale(ctx context.Context, tx *types.Transaction) (bool, error)
+
+	// DecodeTx decodes the call data of a crafted tx into the method and its
+	// arguments, as log context.
+	DecodeTx(tx *types.Transaction) ([]interface{}, error)
+
 	// SendTransaction injects a signed transaction into the pending pool for
 	// execution.
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 }
 
+// L1Client is the L1 node that a Service reads the chain from, and publishes
+// the transactions of its driver to.
+type L1Client interface {
+	txmgr.ReceiptSource
+	txmgr.FeeSource
+	balance.Source
+
+	// NonceAt returns the account nonce of the given account. The block number
+	// can be nil, in which case the nonce is taken from the latest known block.
+	NonceAt(context.Context, common.Address, *big.Int) (uint64, error)
+
+	// CallContract executes a message call against the state at the given
+	// block.
+	CallContract(context.Context, ethereum.CallMsg, *big.Int) ([]byte, error)
+
+	// EstimateGas estimates the gas needed to execute the message call.
+	EstimateGas(context.Context, ethereum.CallMsg) (uint64, error)
+}
+
 type ServiceConfig struct {
 	Context         context.Context
 	Driver          Driver
 	PollInterval    time.Duration
-	L1Client        *ethclient.Client
+	L1Client        L1Client
 	TxManagerConfig txmgr.Config
+	FeePolicyConfig txmgr.FeePolicyConfig
+
+	// MaxPendingTxs is the maximum number of transactions that are submitted
+	// concurrently, allowing the service to catch up after downtime.
+	MaxPendingTxs uint64
+
+	// ScheduleConfig defers submissions while the L1 base fee is high.
+	ScheduleConfig ScheduleConfig
+
+	// BalanceConfig holds the balance thresholds of the wallet.
+	BalanceConfig balance.Config
+
+	// Registry is the metrics registry that the service metrics are
+	// registered in.
+	Registry metrics.Registry
+
+	// MetricsLabel distinguishes the metrics of the service from those of
+	// the services of other drivers in the same registry.
+	MetricsLabel string
 }
 
 type Service struct {
@@ -63,7 +118,17 @@ type Service struct {
 	ctx    context.Context
 	cancel func()
 
-	txMgr txmgr.TxManager
+	txMgr     txmgr.TxManager
+	feePolicy *txmgr.FeePolicy
+	scheduler *scheduler
+	balance   *balance.Monitor
+
+	// published is the last tx published at every nonce that is not mined
+	// yet. It may still be in the tx pool after its Send failed, e.g. if it
+	// was canceled, so a tx crafted again at the same nonce must replace it
+	// with bumped fees.
+	published   map[uint64]*types.Transaction
+	publishedMu sync.Mutex
 
 	wg sync.WaitGroup
 }
@@ -75,14 +140,38 @@ func NewService(cfg ServiceConfig) *Service {
 		cfg.Driver.Name(), cfg.TxManagerConfig, cfg.L1Client,
 	)
 
+	feePolicy := txmgr.NewFeePolicy(cfg.FeePolicyConfig, cfg.L1Client)
+	registry := metrics.NewPrefixedChildRegistry(
+		cfg.Registry, "l2os/"+cfg.MetricsLabel+"/",
+	)
+	scheduler := newScheduler(
+		cfg.Driver.Name(), cfg.ScheduleConfig, registry,
+	)
+
+	walletBalance := balance.NewMonitor(
+		cfg.Driver.WalletAddr(), cfg.BalanceConfig, cfg.L1Client,
+		log.New("service", cfg.Driver.Name()),
+	)
+	walletBalance.RegisterMetrics(registry)
+
 	return &Service{
-		cfg:    cfg,
-		ctx:    ctx,
-		cancel: cancel,
-		txMgr:  txMgr,
+		cfg:       cfg,
+		ctx:       ctx,
+		cancel:    cancel,
+		txMgr:     txMgr,
+		feePolicy: feePolicy,
+		scheduler: scheduler,
+		balance:   walletBalance,
+
+		published: make(map[uint64]*types.Transaction),
 	}
 }
 
+// Balance returns the monitor of the balance of the wallet.
+func (s *Service) Balance() *balance.Monitor {
+	return s.balance
+}
+
 func (s *Service) Start() error {
 	s.wg.Add(1)
 	go s.eventLoop()
@@ -100,24 +189,53 @@ func (s *Service) eventLoop() {
 
 	name := s.cfg.Driver.Name()
 
+	// Pick up the transactions that were in flight before a restart, before
+	// crafting new transactions at the latest nonce.
+	s.resumeTxs()
+
+	pollInterval := s.cfg.PollInterval
 	for {
 		select {
-		case <-time.After(s.cfg.PollInterval):
-			// Determine the range of L2 blocks that the submitter has not
+		case <-time.After(pollInterval):
+			pollInterval = s.cfg.PollInterval
+
+			// Pause submissions while the wallet cannot pay for them.
+			if !s.checkBalance() {
+				continue
+			}
+
+			// Determine the ranges of L2 blocks that the submitter has not
 			// processed, and needs to take action on.
-			log.Info(name + " fetching current block range")
-			start, end, err := s.cfg.Driver.GetBlockRange(s.ctx)
+			log.Info(name + " fetching current block ranges")
+			ranges, err := s.cfg.Driver.GetBlockRanges(
+				s.ctx, int(s.cfg.MaxPendingTxs),
+			)
 			if err != nil {
-				log.Error(name+" unable to get block range", "err", err)
+				log.Error(name+" unable to get block ranges", "err", err)
 				continue
 			}
 
 			// No new updates.
-			if start.Cmp(end) == 0 {
-				log.Info(name+" no updates", "start", start, "end", end)
+			if len(ranges) == 0 {
+				log.Info(name + " no updates")
 				continue
 			}
-			log.Info(name+" block range", "start", start, "end", end)
+			log.Info(name+" block ranges", "start", ranges[0].Start,
+				"end", ranges[len(ranges)-1].End, "txs", len(ranges))
+
+			// Defer the submission while L1 is congested, unless the
+			// deadline of the oldest range has been reached.
+			if s.scheduler.enabled() {
+				header, err := s.cfg.L1Client.HeaderByNumber(s.ctx, nil)
+				if err != nil {
+					log.Error(name+" unable to get latest header",
+						"err", err)
+					continue
+				}
+				if s.scheduler.shouldDefer(header, ranges) {
+					continue
+				}
+			}
 
 			// Query for the submitter's current nonce.
 			nonce64, err := s.cfg.L1Client.NonceAt(
@@ -128,43 +246,286 @@ func (s *Service) eventLoop() {
 					"err", err)
 				continue
 			}
-			nonce := new(big.Int).SetUint64(nonce64)
+			s.forgetPublished(nonce64)
 
-			tx, err := s.cfg.Driver.CraftTx(
-				s.ctx, start, end, nonce,
-			)
-			if err != nil {
-				log.Error(name+" unable to craft tx",
-					"err", err)
-				continue
+			// Craft a transaction for every range, with sequential nonces.
+			// Every range builds on the previous ones, so stop at the first
+			// range that fails.
+			var txs []*types.Transaction
+			for i, r := range ranges {
+				nonce := new(big.Int).SetUint64(nonce64 + uint64(i))
+				tx, err := s.cfg.Driver.CraftTx(
+					s.ctx, r.Start, r.End, nonce,
+				)
+				if err != nil {
+					log.Error(name+" unable to craft tx",
+						"start", r.Start, "end", r.End, "err", err)
+					break
+				}
+				txs = append(txs, tx)
+			}
+
+			// A revert means that the contract state differs from the state
+			// the txs were crafted against, e.g. because another output was
+			// appended, so retrying them is pointless. Re-read the state
+			// right away instead of waiting for the next poll. Crafting
+			// estimates the gas, so txs that would still revert are not
+			// published again. Likewise a stale tx is crafted again right
+			// away, replacing the stale tx in the tx pool.
+			err = s.sendTxs(ranges[:len(txs)], txs)
+			if txmgr.IsRevert(err) || errors.Is(err, txmgr.ErrTxStale) {
+				pollInterval = 0
 			}
 
-			// Construct the a closure that will update the txn with the current
-			// gas prices.
-			updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
-				log.Info(name+" updating batch tx gas price", "start", start,
-					"end", end, "nonce", nonce)
+		case err := <-s.ctx.Done():
+			log.Error(name+" service shutting down", "err", err)
+			return
+		}
+	}
+}
 
-				return s.cfg.Driver.UpdateGasPrice(ctx, tx)
+// sendTxs publishes the transactions concurrently, and waits until all of
+// them are confirmed or failed. It returns the error of the first failed
+// transaction.
+func (s *Service) sendTxs(
+	ranges []drivers.BlockRange, txs []*types.Transaction) error {
+
+	name := s.cfg.Driver.Name()
+
+	return s.publishInOrder(len(txs), func(ctx context.Context, i int) error {
+		tx := txs[i]
+		start, end, nonce := ranges[i].Start, ranges[i].End, tx.Nonce()
+
+		// A tx that was published at the same nonce before may still be in
+		// the tx pool, in which case the new tx must replace it.
+		prev := s.lastPublished(nonce)
+		if prev != nil {
+			log.Info(name+" replacing previously published tx",
+				"start", start, "end", end, "nonce", nonce,
+				"prev_tx_hash", prev.Hash())
+		}
+
+		// Construct the a closure that will update the txn with the fees
+		// picked by the fee policy, bumping them on every resubmission.
+		updateGasPrice := s.feePolicy.ReplaceGasPrice(prev, func(
+			ctx context.Context,
+			gasTipCap, gasFeeCap *big.Int,
+		) (*types.Transaction, error) {
+
+			if err := s.checkStale(ctx, tx); err != nil {
+				return nil, err
 			}
 
-			// Wait until one of our submitted transactions confirms. If no
-			// receipt is received it's likely our gas price was too low.
-			receipt, err := s.txMgr.Send(
-				s.ctx, updateGasPrice, s.cfg.Driver.SendTransaction,
+			log.Info(name+" updating batch tx gas price", "start", start,
+				"end", end, "nonce", nonce, "gasTipCap", gasTipCap,
+				"gasFeeCap", gasFeeCap)
+
+			return s.cfg.Driver.UpdateGasPrice(
+				ctx, tx, gasTipCap, gasFeeCap,
 			)
-			if err != nil {
-				log.Error(name+" unable to publish tx", "err", err)
-				continue
+		})
+
+		// Wait until one of our submitted transactions confirms. If no
+		// receipt is received it's likely our gas price was too low.
+		receipt, err := s.txMgr.Send(ctx, updateGasPrice, s.sendTransaction)
+		var revertErr *txmgr.RevertError
+		if errors.As(err, &revertErr) {
+			log.Error(name+" tx reverted", "start", start, "end", end,
+				"nonce", nonce, "tx_hash", revertErr.Receipt.TxHash,
+				"reason", revertErr.Reason)
+			return err
+		} else if err != nil {
+			log.Error(name+" unable to publish tx", "start", start,
+				"end", end, "nonce", nonce, "err", err)
+			return err
+		}
+
+		// The transaction was successfully submitted.
+		log.Info(name+" tx successfully published",
+			"tx_hash", receipt.TxHash, "nonce", nonce)
+		s.forgetPublished(nonce + 1)
+		return nil
+	})
+}
+
+// checkBalance updates the balance of the wallet, and returns whether
+// submissions may proceed.
+func (s *Service) checkBalance() bool {
+	name := s.cfg.Driver.Name()
+
+	switch err := s.balance.Check(s.ctx); {
+	case errors.Is(err, balance.ErrInsufficientBalance):
+		// The monitor logs the paused submissions.
+		return false
+	case err != nil:
+		log.Error(name+" unable to check wallet balance", "err", err)
+		return false
+	default:
+		return true
+	}
+}
+
+// checkStale returns txmgr.ErrTxStale if the tx can no longer be included as
+// crafted, such that it is crafted again instead of being resubmitted.
+func (s *Service) checkStale(ctx context.Context, tx *types.Transaction) error {
+	stale, err := s.cfg.Driver.IsTxStale(ctx, tx)
+	if err != nil {
+		return err
+	}
+	if stale {
+		return txmgr.ErrTxStale
+	}
+	return nil
+}
+
+// sendTransaction publishes the tx with the driver, and records it as the last
+// published tx of its nonce, unless the L1 node definitely rejected it.
+func (s *Service) sendTransaction(
+	ctx context.Context, tx *types.Transaction) error {
+
+	err := s.cfg.Driver.SendTransaction(ctx, tx)
+	if !txmgr.IsTxRejected(err) {
+		s.recordPublished(tx)
+	}
+	return err
+}
+
+// recordPublished records the tx as the last published tx of its nonce.
+func (s *Service) recordPublished(tx *types.Transaction) {
+	s.publishedMu.Lock()
+	defer s.publishedMu.Unlock()
+
+	s.published[tx.Nonce()] = tx
+}
+
+// lastPublished returns the last published tx of the nonce, or nil if no tx
+// that is not mined yet was published at the nonce.
+func (s *Service) lastPublished(nonce uint64) *types.Transaction {
+	s.publishedMu.Lock()
+	defer s.publishedMu.Unlock()
+
+	return s.published[nonce]
+}
+
+// forgetPublished forgets the published txs of the nonces below the given
+// nonce, which are used by mined txs.
+func (s *Service) forgetPublished(nonce uint64) {
+	s.publishedMu.Lock()
+	defer s.publishedMu.Unlock()
+
+	for n := range s.published {
+		if n < nonce {
+			delete(s.published, n)
+		}
+	}
+}
+
+// resumeTxs reconciles the transactions that were in flight before a restart
+// with the chain, and waits until the pending ones are confirmed or failed,
+// replacing them if they do not confirm in time.
+func (s *Service) resumeTxs() {
+	name := s.cfg.Driver.Name()
+
+	journaled, err := s.txMgr.Reconcile(s.ctx, s.sendTransaction)
+	if err != nil {
+		log.Error(name+" unable to reconcile journaled txs", "err", err)
+		return
+	}
+	if len(journaled) == 0 {
+		return
+	}
+	for _, txs := range journaled {
+		s.recordPublished(txs[len(txs)-1])
+	}
+	log.Info(name+" resuming journaled txs", "txs", len(journaled))
+
+	_ = s.publishInOrder(len(journaled), func(ctx context.Context, i int) error {
+		txs := journaled[i]
+		last := txs[len(txs)-1]
+		nonce := last.Nonce()
+
+		// Replacements bump the fees of the last published transaction.
+		updateGasPrice := s.feePolicy.ReplaceGasPrice(last, func(
+			ctx context.Context,
+			gasTipCap, gasFeeCap *big.Int,
+		) (*types.Transaction, error) {
+
+			if err := s.checkStale(ctx, last); err != nil {
+				return nil, err
 			}
 
-			// The transaction was successfully submitted.
-			log.Info(name+" tx successfully published",
-				"tx_hash", receipt.TxHash)
+			log.Info(name+" updating journaled tx gas price",
+				"nonce", nonce, "gasTipCap", gasTipCap,
+				"gasFeeCap", gasFeeCap)
 
-		case err := <-s.ctx.Done():
-			log.Error(name+" service shutting down", "err", err)
-			return
+			return s.cfg.Driver.UpdateGasPrice(
+				ctx, last, gasTipCap, gasFeeCap,
+			)
+		})
+
+		receipt, err := s.txMgr.Resume(
+			ctx, txs, updateGasPrice, s.sendTransaction,
+		)
+		var revertErr *txmgr.RevertError
+		if errors.As(err, &revertErr) {
+			log.Error(name+" journaled tx reverted", "nonce", nonce,
+				"tx_hash", revertErr.Receipt.TxHash,
+				"reason", revertErr.Reason)
+			return err
+		} else if err != nil {
+			log.Error(name+" unable to publish journaled tx",
+				"nonce", nonce, "err", err)
+			return err
 		}
+
+		log.Info(name+" journaled tx successfully published",
+			"tx_hash", receipt.TxHash, "nonce", nonce)
+		s.forgetPublished(nonce + 1)
+		return nil
+	})
+}
+
+// publishInOrder runs the publish function for n transactions with
+// sequential nonces concurrently, and waits until all of them return. The
+// sequential nonces of the transactions guarantee they are included in order.
+// If a transaction fails, the later transactions can never be included, so
+// they are canceled. Canceled transactions may stay in the tx pool, and are
+// replaced once they are crafted again, see lastPublished. The error of the
+// first failed transaction is returned.
+func (s *Service) publishInOrder(
+	n int, publish func(ctx context.Context, i int) error) error {
+
+	ctxs := make([]context.Context, n)
+	cancels := make([]context.CancelFunc, n)
+	for i := 0; i < n; i++ {
+		ctxs[i], cancels[i] = context.WithCancel(s.ctx)
 	}
+	cancelFrom := func(i int) {
+		for _, cancel := range cancels[i:] {
+			cancel()
+		}
+	}
+	defer cancelFrom(0)
+
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			if err := publish(ctxs[i], i); err != nil {
+				errs[i] = err
+				cancelFrom(i + 1)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
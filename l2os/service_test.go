@@ -0,0 +1,394 @@
+package l2os
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/drivers"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeL1 is an L1 chain with a tx pool, that only accepts replacement txs
+// that bump the fees of the replaced tx by MinBumpPercent.
+type fakeL1 struct {
+	mu       sync.Mutex
+	block    uint64
+	mined    map[common.Address][]*types.Transaction
+	pool     map[common.Address]map[uint64]*types.Transaction
+	receipts map[common.Hash]*types.Receipt
+	// rejected counts the replacement txs that were rejected as underpriced
+	rejected int
+}
+
+func newFakeL1() *fakeL1 {
+	return &fakeL1{
+		block:    1,
+		mined:    make(map[common.Address][]*types.Transaction),
+		pool:     make(map[common.Address]map[uint64]*types.Transaction),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+}
+
+func (l *fakeL1) BlockNumber(ctx context.Context) (uint64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.block, nil
+}
+
+func (l *fakeL1) TransactionReceipt(
+	ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.receipts[txHash], nil
+}
+
+func (l *fakeL1) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(params.GWei), nil
+}
+
+func (l *fakeL1) HeaderByNumber(
+	ctx context.Context, number *big.Int) (*types.Header, error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &types.Header{
+		Number:  new(big.Int).SetUint64(l.block),
+		BaseFee: big.NewInt(10 * params.GWei),
+	}, nil
+}
+
+func (l *fakeL1) BalanceAt(ctx context.Context, account common.Address,
+	blockNumber *big.Int) (*big.Int, error) {
+
+	return big.NewInt(params.Ether), nil
+}
+
+func (l *fakeL1) NonceAt(ctx context.Context, account common.Address,
+	blockNumber *big.Int) (uint64, error) {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return uint64(len(l.mined[account])), nil
+}
+
+func (l *fakeL1) CallContract(ctx context.Context, msg ethereum.CallMsg,
+	blockNumber *big.Int) ([]byte, error) {
+
+	return nil, nil
+}
+
+func (l *fakeL1) EstimateGas(
+	ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+
+	return params.TxGas, nil
+}
+
+func (l *fakeL1) SendTransaction(
+	ctx context.Context, tx *types.Transaction) error {
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if tx.Nonce() < uint64(len(l.mined[from])) {
+		return core.ErrNonceTooLow
+	}
+	pool, ok := l.pool[from]
+	if !ok {
+		pool = make(map[uint64]*types.Transaction)
+		l.pool[from] = pool
+	}
+	if prev, ok := pool[tx.Nonce()]; ok && prev.Hash() != tx.Hash() {
+		if !bumped(prev.GasTipCap(), tx.GasTipCap()) ||
+			!bumped(prev.GasFeeCap(), tx.GasFeeCap()) {
+			l.rejected++
+			return core.ErrReplaceUnderpriced
+		}
+	}
+	pool[tx.Nonce()] = tx
+	return nil
+}
+
+// bumped returns whether the fee is at least MinBumpPercent above prev.
+func bumped(prev, fee *big.Int) bool {
+	min := new(big.Int).Mul(prev, big.NewInt(100+txmgr.MinBumpPercent))
+	return new(big.Int).Mul(fee, big.NewInt(100)).Cmp(min) >= 0
+}
+
+// pending returns the tx of the account in the pool at the given nonce.
+func (l *fakeL1) pending(
+	account common.Address, nonce uint64) *types.Transaction {
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pool[account][nonce]
+}
+
+// rejections returns the number of replacement txs that were rejected as
+// underpriced.
+func (l *fakeL1) rejections() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rejected
+}
+
+// mine includes the next n txs of the account in the pool in a new block,
+// with the given receipt status.
+func (l *fakeL1) mine(account common.Address, n int, status uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.block++
+	for i := 0; i < n; i++ {
+		nonce := uint64(len(l.mined[account]))
+		tx := l.pool[account][nonce]
+		delete(l.pool[account], nonce)
+		l.mined[account] = append(l.mined[account], tx)
+		l.receipts[tx.Hash()] = &types.Receipt{
+			TxHash:      tx.Hash(),
+			Status:      status,
+			BlockNumber: new(big.Int).SetUint64(l.block),
+		}
+	}
+}
+
+// fakeDriver submits one tx per output, until the nonce of its wallet reaches
+// the number of outputs.
+type fakeDriver struct {
+	name    string
+	signer  signer.Signer
+	l1      *fakeL1
+	outputs uint64
+
+	// sendHook, if set, is called before every tx is sent.
+	sendHook func(ctx context.Context)
+
+	mu      sync.Mutex
+	crafted uint64
+	stale   map[common.Hash]bool
+}
+
+func newFakeDriver(t *testing.T, l1 *fakeL1, outputs uint64) *fakeDriver {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	return newFakeDriverWithSigner(l1, signer.NewLocalSigner(key), outputs)
+}
+
+func newFakeDriverWithSigner(
+	l1 *fakeL1, s signer.Signer, outputs uint64) *fakeDriver {
+
+	return &fakeDriver{
+		name:    "test",
+		signer:  s,
+		l1:      l1,
+		outputs: outputs,
+		stale:   make(map[common.Hash]bool),
+	}
+}
+
+func (d *fakeDriver) Name() string {
+	return d.name
+}
+
+func (d *fakeDriver) WalletAddr() common.Address {
+	return d.signer.Address()
+}
+
+func (d *fakeDriver) GetBlockRanges(
+	ctx context.Context, max int) ([]drivers.BlockRange, error) {
+
+	nonce, err := d.l1.NonceAt(ctx, d.WalletAddr(), nil)
+	if err != nil {
+		return nil, err
+	}
+	var ranges []drivers.BlockRange
+	for i := nonce; i < d.outputs && len(ranges) < max; i++ {
+		ranges = append(ranges, drivers.BlockRange{
+			Start: new(big.Int).SetUint64(i),
+			End:   new(big.Int).SetUint64(i + 1),
+		})
+	}
+	return ranges, nil
+}
+
+// CraftTx crafts a distinct tx on every call, like a driver that binds the tx
+// to the latest L1 block.
+func (d *fakeDriver) CraftTx(
+	ctx context.Context, start, end, nonce *big.Int,
+) (*types.Transaction, error) {
+
+	d.mu.Lock()
+	d.crafted++
+	crafted := d.crafted
+	d.mu.Unlock()
+
+	return d.sign(ctx, &types.DynamicFeeTx{
+		Nonce:     nonce.Uint64(),
+		Gas:       params.TxGas,
+		GasTipCap: new(big.Int),
+		GasFeeCap: new(big.Int),
+		To:        &common.Address{0x42},
+		Data:      append(start.Bytes(), byte(crafted)),
+	})
+}
+
+func (d *fakeDriver) UpdateGasPrice(
+	ctx context.Context, tx *types.Transaction,
+	gasTipCap, gasFeeCap *big.Int,
+) (*types.Transaction, error) {
+
+	return d.sign(ctx, &types.DynamicFeeTx{
+		Nonce:     tx.Nonce(),
+		Gas:       tx.Gas(),
+		GasTipCap: gasTipCap,
+		GasFeeCap: gasFeeCap,
+		To:        tx.To(),
+		Data:      tx.Data(),
+	})
+}
+
+func (d *fakeDriver) sign(
+	ctx context.Context, txData types.TxData) (*types.Transaction, error) {
+
+	return d.signer.SignTx(ctx, big.NewInt(1), types.NewTx(txData))
+}
+
+// setStale marks the tx, and all txs with the same call data, as stale.
+func (d *fakeDriver) setStale(tx *types.Transaction) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.stale[crypto.Keccak256Hash(tx.Data())] = true
+}
+
+func (d *fakeDriver) IsTxStale(
+	ctx context.Context, tx *types.Transaction) (bool, error) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stale[crypto.Keccak256Hash(tx.Data())], nil
+}
+
+func (d *fakeDriver) DecodeTx(tx *types.Transaction) ([]interface{}, error) {
+	return []interface{}{"data", tx.Data()}, nil
+}
+
+func (d *fakeDriver) SendTransaction(
+	ctx context.Context, tx *types.Transaction) error {
+
+	if d.sendHook != nil {
+		d.sendHook(ctx)
+	}
+	return d.l1.SendTransaction(ctx, tx)
+}
+
+func newTestService(
+	t *testing.T, l1 L1Client, driver Driver, registry metrics.Registry,
+	label string) *Service {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return NewService(ServiceConfig{
+		Context:      ctx,
+		Driver:       driver,
+		PollInterval: 10 * time.Millisecond,
+		L1Client:     l1,
+		TxManagerConfig: txmgr.Config{
+			ResubmissionTimeout:       100 * time.Millisecond,
+			ReceiptQueryInterval:      10 * time.Millisecond,
+			NumConfirmations:          1,
+			SafeAbortNonceTooLowCount: 3,
+		},
+		FeePolicyConfig: txmgr.FeePolicyConfig{
+			BumpPercent: txmgr.MinBumpPercent,
+		},
+		MaxPendingTxs: 2,
+		Registry:      registry,
+		MetricsLabel:  label,
+	})
+}
+
+// waitFor waits until the condition holds.
+func waitFor(t *testing.T, cond func() bool) {
+	require.Eventually(t, cond, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestServiceReplacesCanceledTxs asserts that txs that are canceled after an
+// earlier tx failed, and stay in the tx pool, are replaced with bumped fees
+// when they are crafted again.
+func TestServiceReplacesCanceledTxs(t *testing.T) {
+	l1 := newFakeL1()
+	driver := newFakeDriver(t, l1, 2)
+	service := newTestService(t, l1, driver, metrics.NewRegistry(), "test")
+	require.NoError(t, service.Start())
+	defer service.Stop()
+
+	wallet := driver.WalletAddr()
+	waitFor(t, func() bool {
+		return l1.pending(wallet, 0) != nil && l1.pending(wallet, 1) != nil
+	})
+	canceled := l1.pending(wallet, 1)
+
+	// The first tx reverts, which cancels the second tx.
+	l1.mine(wallet, 1, types.ReceiptStatusFailed)
+
+	waitFor(t, func() bool {
+		return l1.pending(wallet, 1).Hash() != canceled.Hash()
+	})
+	replacement := l1.pending(wallet, 1)
+	require.True(t, bumped(canceled.GasFeeCap(), replacement.GasFeeCap()))
+	require.Zero(t, l1.rejections())
+
+	l1.mine(wallet, 1, types.ReceiptStatusSuccessful)
+	waitFor(t, func() bool {
+		return service.lastPublished(1) == nil
+	})
+}
+
+// TestServiceReplacesStaleTx asserts that a tx that went stale is crafted
+// again, and replaces the stale tx in the tx pool with bumped fees.
+func TestServiceReplacesStaleTx(t *testing.T) {
+	l1 := newFakeL1()
+	driver := newFakeDriver(t, l1, 1)
+	service := newTestService(t, l1, driver, metrics.NewRegistry(), "test")
+	require.NoError(t, service.Start())
+	defer service.Stop()
+
+	wallet := driver.WalletAddr()
+	waitFor(t, func() bool {
+		return l1.pending(wallet, 0) != nil
+	})
+	stale := l1.pending(wallet, 0)
+
+	// The tx is found stale before its resubmission, and crafted again.
+	driver.setStale(stale)
+	waitFor(t, func() bool {
+		return l1.pending(wallet, 0).Hash() != stale.Hash()
+	})
+	recrafted := l1.pending(wallet, 0)
+	require.NotEqual(t, stale.Data(), recrafted.Data())
+	require.True(t, bumped(stale.GasTipCap(), recrafted.GasTipCap()))
+	require.True(t, bumped(stale.GasFeeCap(), recrafted.GasFeeCap()))
+	require.Zero(t, l1.rejections())
+
+	l1.mine(wallet, 1, types.ReceiptStatusSuccessful)
+	waitFor(t, func() bool {
+		return service.lastPublished(0) == nil
+	})
+}
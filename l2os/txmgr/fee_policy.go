@@ -0,0 +1,257 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// MinBumpPercent is the minimum percentage by which both the gas tip cap and
+// the gas fee cap of a replacement transaction must exceed the fees of the
+// transaction it replaces, for the tx pool to accept the replacement.
+const MinBumpPercent = 10
+
+// ErrFeeCapExceeded signals that the fees required to publish a transaction
+// exceed the operator-set maximum fees.
+var ErrFeeCapExceeded = errors.New("fee cap exceeded")
+
+// FeeSource provides the L1 fee market data that the fees of a transaction are
+// computed from.
+type FeeSource interface {
+	// SuggestGasTipCap retrieves the currently suggested gas tip cap after
+	// 1559 to allow a timely execution of a transaction.
+	SuggestGasTipCap(context.Context) (*big.Int, error)
+
+	// HeaderByNumber returns a block header from the current canonical
+	// chain. If number is nil, the latest known header is returned.
+	HeaderByNumber(context.Context, *big.Int) (*types.Header, error)
+}
+
+// FeePolicyConfig houses parameters for altering the fees picked by a
+// FeePolicy.
+type FeePolicyConfig struct {
+	// FeeHistoryBlocks is the number of recent blocks the base fee is
+	// sampled from. The highest base fee over these blocks is used to
+	// compute the gas fee cap.
+	FeeHistoryBlocks uint64
+
+	// MaxGasTipCap is the maximum gas tip cap, in wei. If nil or zero, the
+	// gas tip cap is not limited.
+	MaxGasTipCap *big.Int
+
+	// MaxGasFeeCap is the maximum gas fee cap, in wei. If nil or zero, the
+	// gas fee cap is not limited.
+	MaxGasFeeCap *big.Int
+
+	// BumpPercent is the percentage by which the fees are bumped on every
+	// resubmission. Values below MinBumpPercent are raised to MinBumpPercent.
+	BumpPercent uint64
+}
+
+// FeePolicy computes EIP-1559 fees for the transactions published by the
+// SimpleTxManager. New transactions pay the suggested gas tip cap, and a gas
+// fee cap computed from the recent base fees with CalcGasFeeCap. Resubmitted
+// transactions pay at least the previous fees bumped by BumpPercent.
+type FeePolicy struct {
+	cfg    FeePolicyConfig
+	source FeeSource
+}
+
+// NewFeePolicy initializes a new FeePolicy with the passed FeePolicyConfig.
+func NewFeePolicy(cfg FeePolicyConfig, source FeeSource) *FeePolicy {
+	if cfg.FeeHistoryBlocks == 0 {
+		cfg.FeeHistoryBlocks = 1
+	}
+	if cfg.BumpPercent < MinBumpPercent {
+		cfg.BumpPercent = MinBumpPercent
+	}
+
+	return &FeePolicy{
+		cfg:    cfg,
+		source: source,
+	}
+}
+
+// Fees returns the gas tip cap and gas fee cap for a new transaction.
+func (p *FeePolicy) Fees(ctx context.Context) (*big.Int, *big.Int, error) {
+	return p.fees(ctx, nil, nil)
+}
+
+// BumpFees returns the gas tip cap and gas fee cap for a transaction that
+// replaces a published transaction with the given fees. Both fees are the
+// maximum of the current network fees and the previous fees bumped by
+// BumpPercent.
+func (p *FeePolicy) BumpFees(
+	ctx context.Context,
+	gasTipCap, gasFeeCap *big.Int,
+) (*big.Int, *big.Int, error) {
+
+	return p.fees(ctx, p.bump(gasTipCap), p.bump(gasFeeCap))
+}
+
+// UpdateGasPrice returns an UpdateGasPriceFunc for a single transaction, that
+// crafts the transaction with the policy fees on the first invocation, and
+// with bumped fees on every later invocation.
+func (p *FeePolicy) UpdateGasPrice(
+	craftTx func(ctx context.Context, gasTipCap, gasFeeCap *big.Int) (*types.Transaction, error),
+) UpdateGasPriceFunc {
+
+	return p.ReplaceGasPrice(nil, craftTx)
+}
+
+// ReplaceGasPrice is like UpdateGasPrice, but the first invocation already
+// bumps the fees of prev, a previously published transaction with the same
+// nonce. If prev is nil, it is equivalent to UpdateGasPrice.
+func (p *FeePolicy) ReplaceGasPrice(
+	prev *types.Transaction,
+	craftTx func(ctx context.Context, gasTipCap, gasFeeCap *big.Int) (*types.Transaction, error),
+) UpdateGasPriceFunc {
+
+	var mu sync.Mutex
+	return func(ctx context.Context) (*types.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		var (
+			gasTipCap, gasFeeCap *big.Int
+			err                  error
+		)
+		if prev == nil {
+			gasTipCap, gasFeeCap, err = p.Fees(ctx)
+		} else {
+			gasTipCap, gasFeeCap, err = p.BumpFees(
+				ctx, prev.GasTipCap(), prev.GasFeeCap(),
+			)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		tx, err := craftTx(ctx, gasTipCap, gasFeeCap)
+		if err != nil {
+			return nil, err
+		}
+		prev = tx
+		return tx, nil
+	}
+}
+
+// fees computes the current network fees, raised to at least the given
+// minimum fees if any, and limited by the maximum fees.
+func (p *FeePolicy) fees(
+	ctx context.Context,
+	minGasTipCap, minGasFeeCap *big.Int,
+) (*big.Int, *big.Int, error) {
+
+	gasTipCap, err := p.source.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to suggest gas tip cap: %w", err)
+	}
+	baseFee, nextBaseFee, err := p.baseFees(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	gasFeeCap := CalcGasFeeCap(baseFee, gasTipCap)
+
+	gasTipCap = maxBig(gasTipCap, minGasTipCap)
+	gasFeeCap = maxBig(gasFeeCap, minGasFeeCap)
+	gasFeeCap = maxBig(gasFeeCap, gasTipCap)
+
+	// The suggested fees may be lowered to the maximum fees, as long as they
+	// still satisfy the minimum fees.
+	gasTipCap, err = limit("gas tip cap", gasTipCap, minGasTipCap,
+		p.cfg.MaxGasTipCap)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The transaction must be able to pay the base fee of the next block to
+	// be included at all.
+	minGasFeeCap = maxBig(minGasFeeCap, new(big.Int).Add(
+		gasTipCap, nextBaseFee,
+	))
+	gasFeeCap, err = limit("gas fee cap", gasFeeCap, minGasFeeCap,
+		p.cfg.MaxGasFeeCap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return gasTipCap, gasFeeCap, nil
+}
+
+// baseFees returns the highest base fee over the last FeeHistoryBlocks blocks,
+// and the highest base fee the next block can have.
+func (p *FeePolicy) baseFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	head, err := p.source.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch latest header: %w", err)
+	}
+	if head.BaseFee == nil {
+		return nil, nil, errors.New("latest header has no base fee")
+	}
+
+	baseFee := head.BaseFee
+	header := head
+	for i := uint64(1); i < p.cfg.FeeHistoryBlocks; i++ {
+		if header.Number.Sign() == 0 {
+			break
+		}
+		number := new(big.Int).Sub(header.Number, common.Big1)
+		header, err = p.source.HeaderByNumber(ctx, number)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to fetch header %v: %w",
+				number, err)
+		}
+		if header.BaseFee == nil {
+			break
+		}
+		baseFee = maxBig(baseFee, header.BaseFee)
+	}
+
+	// The base fee changes by at most 1/BaseFeeChangeDenominator per block.
+	nextBaseFee := new(big.Int).Div(
+		head.BaseFee, big.NewInt(params.BaseFeeChangeDenominator),
+	)
+	nextBaseFee.Add(nextBaseFee, head.BaseFee)
+	return baseFee, nextBaseFee, nil
+}
+
+// bump returns the fee increased by BumpPercent, rounded up, or nil if the fee
+// is nil.
+func (p *FeePolicy) bump(fee *big.Int) *big.Int {
+	if fee == nil {
+		return nil
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+p.cfg.BumpPercent)))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// limit lowers the fee to the maximum fee, and returns ErrFeeCapExceeded if
+// the minimum fee exceeds the maximum fee.
+func limit(name string, fee, min, max *big.Int) (*big.Int, error) {
+	if max == nil || max.Sign() == 0 || fee.Cmp(max) <= 0 {
+		return fee, nil
+	}
+	if min != nil && min.Cmp(max) > 0 {
+		return nil, fmt.Errorf("%w: required %s %v exceeds maximum %v",
+			ErrFeeCapExceeded, name, min, max)
+	}
+	return new(big.Int).Set(max), nil
+}
+
+// maxBig returns the larger of a and b, ignoring nil values.
+func maxBig(a, b *big.Int) *big.Int {
+	if a == nil {
+		return b
+	}
+	if b == nil || a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
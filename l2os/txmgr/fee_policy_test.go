@@ -0,0 +1,181 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/mock"
+	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// newFeeL1Client returns a mock L1Client that suggests the given tip, with a
+// chain whose latest block has the last of the given base fees.
+func newFeeL1Client(tip int64, baseFees ...int64) *mock.L1Client {
+	head := int64(100)
+	return mock.NewL1Client(mock.L1ClientConfig{
+		SuggestGasTipCap: func(context.Context) (*big.Int, error) {
+			return big.NewInt(tip), nil
+		},
+		HeaderByNumber: func(_ context.Context, number *big.Int) (*types.Header, error) {
+			if number == nil {
+				number = big.NewInt(head)
+			}
+			i := len(baseFees) - 1 - int(head-number.Int64())
+			if i < 0 {
+				return nil, errors.New("unknown block")
+			}
+			return &types.Header{
+				Number:  number,
+				BaseFee: big.NewInt(baseFees[i]),
+			}, nil
+		},
+	})
+}
+
+func TestFeePolicyFees(t *testing.T) {
+	l1Client := newFeeL1Client(2, 50, 100, 80)
+	policy := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		FeeHistoryBlocks: 3,
+	}, l1Client)
+
+	gasTipCap, gasFeeCap, err := policy.Fees(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), gasTipCap)
+	// The highest base fee of the sampled blocks is used.
+	require.Equal(t, txmgr.CalcGasFeeCap(big.NewInt(100), gasTipCap), gasFeeCap)
+
+	// Only the latest block is sampled.
+	policy = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{}, l1Client)
+	_, gasFeeCap, err = policy.Fees(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, txmgr.CalcGasFeeCap(big.NewInt(80), gasTipCap), gasFeeCap)
+}
+
+func TestFeePolicyBumpFees(t *testing.T) {
+	l1Client := newFeeL1Client(2, 100)
+	policy := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		FeeHistoryBlocks: 1,
+	}, l1Client)
+
+	// The previous fees are bumped by at least the replacement minimum, even
+	// if the network fees are lower.
+	gasTipCap, gasFeeCap, err := policy.BumpFees(
+		context.Background(), big.NewInt(10), big.NewInt(1000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(11), gasTipCap)
+	require.Equal(t, big.NewInt(1100), gasFeeCap)
+
+	// Bumped fees are rounded up.
+	gasTipCap, _, err = policy.BumpFees(
+		context.Background(), big.NewInt(1), big.NewInt(1000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), gasTipCap)
+
+	// The network fees are used if they are higher than the bumped fees.
+	gasTipCap, gasFeeCap, err = policy.BumpFees(
+		context.Background(), big.NewInt(1), big.NewInt(100),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), gasTipCap)
+	require.Equal(t, big.NewInt(202), gasFeeCap)
+
+	// Bump percentages below the replacement minimum are raised.
+	policy = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		BumpPercent: 5,
+	}, l1Client)
+	_, gasFeeCap, err = policy.BumpFees(
+		context.Background(), big.NewInt(10), big.NewInt(1000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1100), gasFeeCap)
+
+	policy = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		BumpPercent: 50,
+	}, l1Client)
+	_, gasFeeCap, err = policy.BumpFees(
+		context.Background(), big.NewInt(10), big.NewInt(1000),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1500), gasFeeCap)
+}
+
+func TestFeePolicyMaxFees(t *testing.T) {
+	l1Client := newFeeL1Client(20, 100)
+	policy := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		MaxGasTipCap: big.NewInt(10),
+		MaxGasFeeCap: big.NewInt(150),
+	}, l1Client)
+
+	// The suggested fees are lowered to the maximum fees.
+	gasTipCap, gasFeeCap, err := policy.Fees(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), gasTipCap)
+	require.Equal(t, big.NewInt(150), gasFeeCap)
+
+	// Bumped fees are lowered to the maximum fees if the bump allows it.
+	gasTipCap, gasFeeCap, err = policy.BumpFees(
+		context.Background(), big.NewInt(9), big.NewInt(130),
+	)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), gasTipCap)
+	require.Equal(t, big.NewInt(150), gasFeeCap)
+
+	// The bump would exceed the maximum gas fee cap.
+	_, _, err = policy.BumpFees(
+		context.Background(), big.NewInt(9), big.NewInt(140),
+	)
+	require.True(t, errors.Is(err, txmgr.ErrFeeCapExceeded))
+
+	// The bump would exceed the maximum gas tip cap.
+	_, _, err = policy.BumpFees(
+		context.Background(), big.NewInt(10), big.NewInt(130),
+	)
+	require.True(t, errors.Is(err, txmgr.ErrFeeCapExceeded))
+
+	// The maximum gas fee cap cannot pay the base fee of the next block.
+	policy = txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		MaxGasFeeCap: big.NewInt(100),
+	}, l1Client)
+	_, _, err = policy.Fees(context.Background())
+	require.True(t, errors.Is(err, txmgr.ErrFeeCapExceeded))
+}
+
+func TestFeePolicyUpdateGasPrice(t *testing.T) {
+	l1Client := newFeeL1Client(10, 100)
+	policy := txmgr.NewFeePolicy(txmgr.FeePolicyConfig{
+		MaxGasFeeCap: big.NewInt(300),
+	}, l1Client)
+
+	updateGasPrice := policy.UpdateGasPrice(func(
+		_ context.Context, gasTipCap, gasFeeCap *big.Int,
+	) (*types.Transaction, error) {
+
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	})
+
+	expected := []struct{ tip, feeCap int64 }{
+		{10, 210},
+		{11, 231},
+		{13, 255},
+		{15, 281},
+	}
+	for _, fees := range expected {
+		tx, err := updateGasPrice(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(fees.tip), tx.GasTipCap())
+		require.Equal(t, big.NewInt(fees.feeCap), tx.GasFeeCap())
+	}
+
+	// The next bump exceeds the maximum gas fee cap.
+	_, err := updateGasPrice(context.Background())
+	require.True(t, errors.Is(err, txmgr.ErrFeeCapExceeded))
+}
@@ -0,0 +1,207 @@
+package txmgr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// JournalEntry is a transaction that is about to be published, or published
+// and not confirmed yet.
+type JournalEntry struct {
+	Hash      common.Hash    `json:"hash"`
+	Nonce     hexutil.Uint64 `json:"nonce"`
+	GasTipCap *hexutil.Big   `json:"gasTipCap"`
+	GasFeeCap *hexutil.Big   `json:"gasFeeCap"`
+	Raw       hexutil.Bytes  `json:"raw"`
+}
+
+// Transaction decodes the raw transaction of the entry.
+func (e *JournalEntry) Transaction() (*types.Transaction, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(e.Raw); err != nil {
+		return nil, fmt.Errorf("invalid journaled tx %s: %w", e.Hash, err)
+	}
+	if tx.Hash() != e.Hash {
+		return nil, fmt.Errorf("journaled tx %s has hash %s", e.Hash,
+			tx.Hash())
+	}
+	return &tx, nil
+}
+
+// Journal persists the transactions published by the SimpleTxManager until
+// they confirm, such that a restarted process can pick up the transactions
+// that were in flight, instead of publishing conflicting transactions.
+//
+// The journal is a JSON file that is rewritten on every change.
+type Journal struct {
+	path    string
+	entries []*JournalEntry
+	mu      sync.Mutex
+}
+
+// OpenJournal loads the journal at the given path, or creates an empty
+// journal if the file does not exist yet.
+func OpenJournal(path string) (*Journal, error) {
+	j := &Journal{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read tx journal: %w", err)
+	}
+	if err := json.Unmarshal(data, &j.entries); err != nil {
+		return nil, fmt.Errorf("unable to decode tx journal %s: %w", path,
+			err)
+	}
+	for _, entry := range j.entries {
+		if _, err := entry.Transaction(); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// Add records a transaction before it is published, such that a crash while
+// publishing does not lose track of it.
+func (j *Journal) Add(tx *types.Transaction) error {
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, entry := range j.entries {
+		if entry.Hash == tx.Hash() {
+			return nil
+		}
+	}
+	j.entries = append(j.entries, &JournalEntry{
+		Hash:      tx.Hash(),
+		Nonce:     hexutil.Uint64(tx.Nonce()),
+		GasTipCap: (*hexutil.Big)(tx.GasTipCap()),
+		GasFeeCap: (*hexutil.Big)(tx.GasFeeCap()),
+		Raw:       raw,
+	})
+	return j.write()
+}
+
+// Confirm removes the confirmed transaction, and all transactions with the
+// same nonce that it replaced or was replaced by.
+func (j *Journal) Confirm(txHash common.Hash) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, entry := range j.entries {
+		if entry.Hash == txHash {
+			return j.remove(uint64(entry.Nonce))
+		}
+	}
+	return nil
+}
+
+// Remove removes all transactions with the given nonce.
+func (j *Journal) Remove(nonce uint64) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.remove(nonce)
+}
+
+// Discard removes the single transaction with the given hash, e.g. if it was
+// rejected by the backend and never published. Other transactions with the
+// same nonce are kept.
+func (j *Journal) Discard(txHash common.Hash) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for i, entry := range j.entries {
+		if entry.Hash == txHash {
+			j.entries = append(j.entries[:i], j.entries[i+1:]...)
+			return j.write()
+		}
+	}
+	return nil
+}
+
+// Pending returns the journaled transactions grouped by nonce, in nonce
+// order. The transactions of a nonce are in the order they were published,
+// such that the last one has the highest fees.
+func (j *Journal) Pending() ([][]*types.Transaction, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	byNonce := make(map[uint64][]*types.Transaction)
+	var nonces []uint64
+	for _, entry := range j.entries {
+		tx, err := entry.Transaction()
+		if err != nil {
+			return nil, err
+		}
+		nonce := uint64(entry.Nonce)
+		if _, ok := byNonce[nonce]; !ok {
+			nonces = append(nonces, nonce)
+		}
+		byNonce[nonce] = append(byNonce[nonce], tx)
+	}
+	sort.Slice(nonces, func(a, b int) bool { return nonces[a] < nonces[b] })
+
+	pending := make([][]*types.Transaction, 0, len(nonces))
+	for _, nonce := range nonces {
+		pending = append(pending, byNonce[nonce])
+	}
+	return pending, nil
+}
+
+func (j *Journal) remove(nonce uint64) error {
+	entries := j.entries[:0]
+	for _, entry := range j.entries {
+		if uint64(entry.Nonce) != nonce {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == len(j.entries) {
+		return nil
+	}
+	j.entries = entries
+	return j.write()
+}
+
+// write atomically replaces the journal file with the current entries.
+func (j *Journal) write() error {
+	data, err := json.MarshalIndent(j.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("unable to write tx journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write tx journal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write tx journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write tx journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), j.path); err != nil {
+		return fmt.Errorf("unable to write tx journal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func journalTx(nonce uint64, gasFeeCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(gasFeeCap),
+	})
+}
+
+func txHashes(txs []*types.Transaction) []common.Hash {
+	hashes := make([]common.Hash, len(txs))
+	for i, tx := range txs {
+		hashes[i] = tx.Hash()
+	}
+	return hashes
+}
+
+func openJournal(t *testing.T, path string) *txmgr.Journal {
+	journal, err := txmgr.OpenJournal(path)
+	require.NoError(t, err)
+	return journal
+}
+
+func TestJournalPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal := openJournal(t, path)
+
+	pending, err := journal.Pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	tx1, tx1Replacement, tx2 := journalTx(1, 10), journalTx(1, 11), journalTx(2, 10)
+	require.NoError(t, journal.Add(tx2))
+	require.NoError(t, journal.Add(tx1))
+	require.NoError(t, journal.Add(tx1Replacement))
+	require.NoError(t, journal.Add(tx1Replacement))
+
+	// The journal is loaded again after a restart, grouped by nonce.
+	pending, err = openJournal(t, path).Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	require.Len(t, pending[0], 2)
+	require.Equal(t, tx1.Hash(), pending[0][0].Hash())
+	require.Equal(t, tx1Replacement.Hash(), pending[0][1].Hash())
+	require.Len(t, pending[1], 1)
+	require.Equal(t, tx2.Hash(), pending[1][0].Hash())
+
+	// Confirming a tx removes all txs with the same nonce.
+	require.NoError(t, journal.Confirm(tx1.Hash()))
+	pending, err = openJournal(t, path).Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, tx2.Hash(), pending[0][0].Hash())
+
+	require.NoError(t, journal.Remove(2))
+	pending, err = openJournal(t, path).Pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+// TestTxMgrJournalsUntilConfirmed asserts that Send journals the published tx
+// until it confirms.
+func TestTxMgrJournalsUntilConfirmed(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.Journal = openJournal(t, filepath.Join(t.TempDir(), "journal.json"))
+	h := newTestHarnessWithConfig(cfg)
+
+	tx := journalTx(1, 10)
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		return tx, nil
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		// Don't publish tx to backend, simulating never being mined.
+		return nil
+	}
+
+	// The tx is still journaled if Send is interrupted.
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	_, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	pending, err := cfg.Journal.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, tx.Hash(), pending[0][0].Hash())
+
+	sendTx = func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), receipt.TxHash)
+
+	pending, err = cfg.Journal.Pending()
+	require.NoError(t, err)
+	require.Empty(t, pending)
+}
+
+// TestTxMgrReconcileAndResume asserts that journaled txs are reconciled
+// against the chain, and that resumed txs confirm without being replaced if
+// mined in time.
+func TestTxMgrReconcileAndResume(t *testing.T) {
+	t.Parallel()
+
+	cfg := configWithNumConfs(1)
+	cfg.Journal = openJournal(t, filepath.Join(t.TempDir(), "journal.json"))
+	h := newTestHarnessWithConfig(cfg)
+
+	mined, pending, used := journalTx(1, 10), journalTx(2, 10), journalTx(3, 10)
+	pendingReplacement := journalTx(2, 11)
+	for _, tx := range []*types.Transaction{mined, pending, pendingReplacement, used} {
+		require.NoError(t, cfg.Journal.Add(tx))
+	}
+	minedHash := mined.Hash()
+	h.backend.mine(&minedHash, mined.GasFeeCap())
+
+	var rebroadcast []*types.Transaction
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		rebroadcast = append(rebroadcast, tx)
+		if tx.Nonce() == used.Nonce() {
+			return core.ErrNonceTooLow
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	txs, err := h.mgr.Reconcile(ctx, sendTx)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	require.Equal(t, []common.Hash{pending.Hash(), pendingReplacement.Hash()},
+		txHashes(txs[0]))
+	// Only the last tx of every unmined nonce is rebroadcast.
+	require.Equal(t, []common.Hash{pendingReplacement.Hash(), used.Hash()},
+		txHashes(rebroadcast))
+
+	journaled, err := cfg.Journal.Pending()
+	require.NoError(t, err)
+	require.Len(t, journaled, 1)
+	require.Equal(t, txHashes(txs[0]), txHashes(journaled[0]))
+
+	// The first published tx is mined after the restart.
+	pendingHash := pending.Hash()
+	h.backend.mine(&pendingHash, pending.GasFeeCap())
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		return nil, errors.New("journaled tx should not be replaced")
+	}
+	receipt, err := h.mgr.Resume(ctx, txs[0], updateGasPrice, sendTx)
+	require.NoError(t, err)
+	require.Equal(t, pendingHash, receipt.TxHash)
+
+	journaled, err = cfg.Journal.Pending()
+	require.NoError(t, err)
+	require.Empty(t, journaled)
+}
+
+// TestTxMgrJournalsBeforePublishing asserts that Send journals a tx before it
+// is published, and discards it if the backend rejects it.
+func TestTxMgrJournalsBeforePublishing(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name     string
+		sendErr  error
+		rejected bool
+	}{
+		{"rejected", core.ErrReplaceUnderpriced, true},
+		{"rejected over rpc", errors.New("rpc error: nonce too high"), true},
+		{"network error", errors.New("connection reset by peer"), false},
+	} {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := configWithNumConfs(1)
+			cfg.Journal = openJournal(t, filepath.Join(t.TempDir(), "journal.json"))
+			h := newTestHarnessWithConfig(cfg)
+
+			tx := journalTx(1, 10)
+			updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+				return tx, nil
+			}
+			published := make(chan struct{}, 1)
+			sendTx := func(ctx context.Context, tx *types.Transaction) error {
+				pending, err := cfg.Journal.Pending()
+				require.NoError(t, err)
+				require.Len(t, pending, 1)
+				require.Equal(t, tx.Hash(), pending[0][0].Hash())
+				select {
+				case published <- struct{}{}:
+				default:
+				}
+				return test.sendErr
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				_, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+				done <- err
+			}()
+			<-published
+			cancel()
+			require.Equal(t, context.Canceled, <-done)
+
+			pending, err := cfg.Journal.Pending()
+			require.NoError(t, err)
+			if test.rejected {
+				require.Empty(t, pending)
+			} else {
+				require.Len(t, pending, 1)
+			}
+		})
+	}
+}
+
+func TestJournalDiscard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+	journal := openJournal(t, path)
+
+	tx1, tx1Replacement := journalTx(1, 10), journalTx(1, 11)
+	require.NoError(t, journal.Add(tx1))
+	require.NoError(t, journal.Add(tx1Replacement))
+
+	require.NoError(t, journal.Discard(tx1Replacement.Hash()))
+	require.NoError(t, journal.Discard(tx1Replacement.Hash()))
+	pending, err := openJournal(t, path).Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	require.Equal(t, txHashes([]*types.Transaction{tx1}), txHashes(pending[0]))
+}
@@ -0,0 +1,104 @@
+package txmgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RevertError is returned by Send when the transaction was mined, but
+// reverted. Unlike network errors, retrying the same transaction is pointless:
+// the caller must re-read the contract state it was crafted against.
+type RevertError struct {
+	// Receipt is the receipt of the reverted transaction.
+	Receipt *types.Receipt
+
+	// Reason is the revert reason obtained by replaying the transaction, or
+	// empty if it is unknown, e.g. because the transaction ran out of gas.
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	reason := e.Reason
+	if reason == "" {
+		reason = "unknown reason"
+	}
+	return fmt.Sprintf("tx %s reverted in block %d: %s", e.Receipt.TxHash,
+		e.Receipt.BlockNumber, reason)
+}
+
+// IsRevert returns whether the error is, or wraps, a RevertError.
+func IsRevert(err error) bool {
+	var revertErr *RevertError
+	return errors.As(err, &revertErr)
+}
+
+// CallSource replays transactions to obtain their revert reason. If the
+// backend of the SimpleTxManager implements it, RevertErrors carry the revert
+// reason.
+//
+// NOTE: This is a subset of bind.ContractCaller.
+type CallSource interface {
+	// CallContract executes a message call against the state at the given
+	// block.
+	CallContract(
+		ctx context.Context,
+		msg ethereum.CallMsg,
+		blockNumber *big.Int,
+	) ([]byte, error)
+}
+
+// RevertReason replays the transaction as a call at the given block, and
+// returns the reason it reverts with. An empty reason is returned if the call
+// does not revert, or reverts without a reason.
+func RevertReason(
+	ctx context.Context,
+	backend CallSource,
+	tx *types.Transaction,
+	blockNumber *big.Int,
+) (string, error) {
+
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = backend.CallContract(ctx, ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}, blockNumber)
+	if err == nil {
+		return "", nil
+	}
+
+	// Prefer decoding the revert data, which nodes return as error data.
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if raw, err := hexutil.Decode(data); err == nil {
+				if reason, err := abi.UnpackRevert(raw); err == nil {
+					return reason, nil
+				}
+			}
+		}
+	}
+
+	const prefix = "execution reverted"
+	msg := err.Error()
+	if !strings.HasPrefix(msg, prefix) {
+		// The replay failed for another reason, e.g. a network error.
+		return "", err
+	}
+	return strings.TrimPrefix(strings.TrimPrefix(msg, prefix), ": "), nil
+}
@@ -0,0 +1,183 @@
+package txmgr_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// dataError is a JSON-RPC error carrying revert data, like the errors of
+// eth_call.
+type dataError struct {
+	msg  string
+	data string
+}
+
+func (e *dataError) Error() string          { return e.msg }
+func (e *dataError) ErrorData() interface{} { return e.data }
+
+// revertData ABI-encodes the revert reason like Solidity's Error(string).
+func revertData(t *testing.T, reason string) []byte {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(reason)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte("Error(string)"))[:4], data...)
+}
+
+// callingBackend is a mockBackend that replays calls with a fixed error.
+type callingBackend struct {
+	*mockBackend
+	callErr     error
+	blockNumber *big.Int
+}
+
+func (b *callingBackend) CallContract(
+	ctx context.Context,
+	msg ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+
+	b.blockNumber = blockNumber
+	return nil, b.callErr
+}
+
+func signedTx(t *testing.T, gasTipCap, gasFeeCap *big.Int) *types.Transaction {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := types.SignNewTx(
+		key, types.LatestSignerForChainID(big.NewInt(1)),
+		&types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       100_000,
+			To:        &common.Address{0x01},
+		},
+	)
+	require.NoError(t, err)
+	return tx
+}
+
+func TestRevertReason(t *testing.T) {
+	ctx := context.Background()
+	tx := signedTx(t, big.NewInt(1), big.NewInt(2))
+	reason := "Timestamp not equal to next expected timestamp"
+
+	// The revert data is decoded.
+	backend := &callingBackend{callErr: &dataError{
+		msg:  "execution reverted: " + reason,
+		data: hexutil.Encode(revertData(t, reason)),
+	}}
+	decoded, err := txmgr.RevertReason(ctx, backend, tx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, reason, decoded)
+	require.Equal(t, big.NewInt(5), backend.blockNumber)
+
+	// Without revert data, the reason is taken from the error message.
+	backend.callErr = errors.New("execution reverted: " + reason)
+	decoded, err = txmgr.RevertReason(ctx, backend, tx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, reason, decoded)
+
+	// A revert without a reason.
+	backend.callErr = errors.New("execution reverted")
+	decoded, err = txmgr.RevertReason(ctx, backend, tx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, "", decoded)
+
+	// A replay that does not revert.
+	backend.callErr = nil
+	decoded, err = txmgr.RevertReason(ctx, backend, tx, big.NewInt(5))
+	require.NoError(t, err)
+	require.Equal(t, "", decoded)
+
+	// A replay that fails for another reason.
+	backend.callErr = errors.New("connection refused")
+	_, err = txmgr.RevertReason(ctx, backend, tx, big.NewInt(5))
+	require.Error(t, err)
+}
+
+// TestTxMgrReturnsRevertError asserts that Send returns a RevertError with the
+// revert reason if the tx is mined, but reverts.
+func TestTxMgrReturnsRevertError(t *testing.T) {
+	t.Parallel()
+
+	reason := "Blockhash does not match the hash at the expected height."
+	backend := &callingBackend{
+		mockBackend: newMockBackend(),
+		callErr: &dataError{
+			msg:  "execution reverted: " + reason,
+			data: hexutil.Encode(revertData(t, reason)),
+		},
+	}
+	mgr := txmgr.NewSimpleTxManager("TEST", configWithNumConfs(1), backend)
+
+	tx := signedTx(t, big.NewInt(1), big.NewInt(2))
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		return tx, nil
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		backend.mineReverted(&txHash, tx.GasFeeCap())
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := mgr.Send(ctx, updateGasPrice, sendTx)
+	require.Nil(t, receipt)
+	require.True(t, txmgr.IsRevert(err))
+
+	var revertErr *txmgr.RevertError
+	require.True(t, errors.As(err, &revertErr))
+	require.Equal(t, tx.Hash(), revertErr.Receipt.TxHash)
+	require.Equal(t, reason, revertErr.Reason)
+
+	// The tx is replayed at its inclusion block.
+	require.Equal(t, revertErr.Receipt.BlockNumber, backend.blockNumber)
+}
+
+// TestTxMgrRevertWithoutCallSource asserts that Send returns a RevertError
+// without a reason if the backend cannot replay the tx.
+func TestTxMgrRevertWithoutCallSource(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mineReverted(&txHash, tx.GasFeeCap())
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.Nil(t, receipt)
+
+	var revertErr *txmgr.RevertError
+	require.True(t, errors.As(err, &revertErr))
+	require.Equal(t, "", revertErr.Reason)
+	require.Contains(t, err.Error(), "unknown reason")
+}
@@ -2,12 +2,15 @@ package txmgr
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -19,6 +22,44 @@ type UpdateGasPriceFunc = func(ctx context.Context) (*types.Transaction, error)
 
 type SendTransactionFunc = func(ctx context.Context, tx *types.Transaction) error
 
+// ErrTxStale signals that a transaction can no longer be included as crafted,
+// e.g. because the L1 state it was crafted against was reorged out, and must
+// be crafted again.
+var ErrTxStale = errors.New("transaction is stale")
+
+// rejectionErrors are the tx pool and state transition errors, with which the
+// backend definitely rejects a transaction.
+var rejectionErrors = []error{
+	core.ErrNonceTooLow,
+	core.ErrNonceTooHigh,
+	core.ErrInsufficientFunds,
+	core.ErrIntrinsicGas,
+	core.ErrGasLimit,
+	core.ErrTipAboveFeeCap,
+	core.ErrFeeCapTooLow,
+	core.ErrUnderpriced,
+	core.ErrReplaceUnderpriced,
+	core.ErrTxPoolOverflow,
+	core.ErrOversizedData,
+}
+
+// IsTxRejected returns whether the error returned by publishing a transaction
+// is a definite rejection by the backend, as opposed to e.g. a network error,
+// after which the transaction may or may not have been published. Like the
+// other send errors, rejections are matched by message, since they are
+// reported over JSON-RPC.
+func IsTxRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	for _, rejection := range rejectionErrors {
+		if strings.Contains(err.Error(), rejection.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
 // Config houses parameters for altering the behavior of a SimpleTxManager.
 type Config struct {
 	// Name the name of the driver to appear in log lines.
@@ -43,6 +84,11 @@ type Config struct {
 	// are required to give up on a tx at a particular nonce without receiving
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
+
+	// Journal, if set, records every tx before it is published, until it
+	// confirms or is rejected, such that in-flight txs can be resumed after a
+	// restart.
+	Journal *Journal
 }
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -51,14 +97,32 @@ type TxManager interface {
 	// Send is used to publish a transaction with incrementally higher gas
 	// prices until the transaction eventually confirms. This method blocks
 	// until an invocation of sendTx returns (called with differing gas
-	// prices). The method may be canceled using the passed context.
+	// prices). The method may be canceled using the passed context. If the
+	// transaction reverts, a RevertError is returned.
 	//
-	// NOTE: Send should be called by AT MOST one caller at a time.
+	// NOTE: Concurrent callers MUST publish transactions with distinct
+	// nonces.
 	Send(
 		ctx context.Context,
 		updateGasPrice UpdateGasPriceFunc,
 		sendTxn SendTransactionFunc,
 	) (*types.Receipt, error)
+
+	// Resume is like Send, but first waits for the given txs with the same
+	// nonce, published before, to confirm.
+	Resume(
+		ctx context.Context,
+		txs []*types.Transaction,
+		updateGasPrice UpdateGasPriceFunc,
+		sendTxn SendTransactionFunc,
+	) (*types.Receipt, error)
+
+	// Reconcile checks the journaled txs against the chain, rebroadcasting
+	// the pending ones, and returns the txs of the pending nonces.
+	Reconcile(
+		ctx context.Context,
+		sendTxn SendTransactionFunc,
+	) ([][]*types.Transaction, error)
 }
 
 // ReceiptSource is a minimal function signature used to detect the confirmation
@@ -102,15 +166,122 @@ func NewSimpleTxManager(
 // Send is used to publish a transaction with incrementally higher gas prices
 // until the transaction eventually confirms. This method blocks until an
 // invocation of sendTx returns (called with differing gas prices). The method
-// may be canceled using the passed context.
+// may be canceled using the passed context. If updateGasPrice returns
+// ErrFeeCapExceeded or ErrTxStale, Send gives up and returns the error. If
+// the transaction reverts, Send returns a RevertError.
 //
-// NOTE: Send should be called by AT MOST one caller at a time.
+// NOTE: Concurrent callers MUST publish transactions with distinct nonces.
 func (m *SimpleTxManager) Send(
 	ctx context.Context,
 	updateGasPrice UpdateGasPriceFunc,
 	sendTx SendTransactionFunc,
 ) (*types.Receipt, error) {
 
+	return m.send(ctx, nil, updateGasPrice, sendTx)
+}
+
+// Resume waits for txs with the same nonce that were published before, e.g.
+// by a previous process, to confirm. If none of them is mined within the
+// resubmission timeout, they are replaced with txs with incrementally higher
+// gas prices like in Send.
+//
+// NOTE: Concurrent callers MUST publish transactions with distinct nonces.
+func (m *SimpleTxManager) Resume(
+	ctx context.Context,
+	txs []*types.Transaction,
+	updateGasPrice UpdateGasPriceFunc,
+	sendTx SendTransactionFunc,
+) (*types.Receipt, error) {
+
+	return m.send(ctx, txs, updateGasPrice, sendTx)
+}
+
+// Reconcile checks the journaled txs against the chain, e.g. after a restart.
+// Nonces that are used on chain are removed from the journal, and the last
+// published tx of every other nonce is rebroadcast. The journaled txs of the
+// pending nonces are returned in nonce order, to be passed to Resume.
+func (m *SimpleTxManager) Reconcile(
+	ctx context.Context,
+	sendTx SendTransactionFunc,
+) ([][]*types.Transaction, error) {
+
+	if m.cfg.Journal == nil {
+		return nil, nil
+	}
+	name := m.name
+
+	journaled, err := m.cfg.Journal.Pending()
+	if err != nil {
+		return nil, err
+	}
+
+	var pending [][]*types.Transaction
+	for _, txs := range journaled {
+		nonce := txs[0].Nonce()
+
+		mined, err := m.anyMined(ctx, txs)
+		if err != nil {
+			return nil, err
+		}
+		if mined {
+			log.Info(name+" journaled transaction was mined", "nonce", nonce)
+			if err := m.cfg.Journal.Remove(nonce); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		last := txs[len(txs)-1]
+		err = sendTx(ctx, last)
+		switch {
+		case err == nil:
+			log.Info(name+" rebroadcast journaled transaction",
+				"hash", last.Hash(), "nonce", nonce)
+		case strings.Contains(err.Error(), core.ErrNonceTooLow.Error()):
+			// Another transaction used the nonce.
+			log.Warn(name+" journaled transaction nonce was used by "+
+				"another transaction", "nonce", nonce)
+			if err := m.cfg.Journal.Remove(nonce); err != nil {
+				return nil, err
+			}
+			continue
+		default:
+			// The transaction may still be in the tx pool, e.g. if it
+			// is already known. Otherwise it is replaced by Resume.
+			log.Warn(name+" unable to rebroadcast journaled transaction",
+				"hash", last.Hash(), "nonce", nonce, "err", err)
+		}
+		pending = append(pending, txs)
+	}
+	return pending, nil
+}
+
+// anyMined returns whether any of the txs has a receipt.
+func (m *SimpleTxManager) anyMined(
+	ctx context.Context, txs []*types.Transaction) (bool, error) {
+
+	for _, tx := range txs {
+		receipt, err := m.backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return false, err
+		}
+		if receipt != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// send implements Send and Resume. The prev txs are waited on as if they were
+// published by send, in which case the first tx is published after the
+// resubmission timeout.
+func (m *SimpleTxManager) send(
+	ctx context.Context,
+	prev []*types.Transaction,
+	updateGasPrice UpdateGasPriceFunc,
+	sendTx SendTransactionFunc,
+) (*types.Receipt, error) {
+
 	name := m.name
 
 	// Initialize a wait group to track any spawned goroutines, and ensure
@@ -129,8 +300,10 @@ func (m *SimpleTxManager) Send(
 
 	// Create a closure that will block on passed sendTx function in the
 	// background, returning the first successfully mined receipt back to
-	// the main event loop via receiptChan.
-	receiptChan := make(chan *types.Receipt, 1)
+	// the main event loop via receiptChan, along with its tx.
+	receiptChan := make(chan minedTx, 1)
+	abortChan := make(chan error, 1)
+	var waitMinedAsync func(tx *types.Transaction)
 	sendTxAsync := func() {
 		defer wg.Done()
 
@@ -141,6 +314,17 @@ func (m *SimpleTxManager) Send(
 				return
 			}
 			log.Error(name+" unable to update txn gas price", "err", err)
+
+			// Every resubmission must bump the fees further, so once the
+			// fee cap is hit the tx can no longer be replaced. A stale tx
+			// must not be replaced, but crafted again by the caller.
+			if errors.Is(err, ErrFeeCapExceeded) ||
+				errors.Is(err, ErrTxStale) {
+				select {
+				case abortChan <- err:
+				default:
+				}
+			}
 			return
 		}
 
@@ -151,10 +335,28 @@ func (m *SimpleTxManager) Send(
 		log.Info(name+" publishing transaction", "txHash", txHash,
 			"nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap)
 
+		// Journal the transaction before publishing it, such that it is
+		// reconciled after a crash, even if the backend accepted it but the
+		// response was lost.
+		if m.cfg.Journal != nil {
+			if err := m.cfg.Journal.Add(tx); err != nil {
+				log.Error(name+" unable to journal transaction",
+					"hash", txHash, "err", err)
+			}
+		}
+
 		// Sign and publish transaction with current gas price.
 		err = sendTx(ctxc, tx)
 		sendState.ProcessSendError(err)
 		if err != nil {
+			// The backend definitely did not accept the transaction, it
+			// must not be reconciled.
+			if m.cfg.Journal != nil && IsTxRejected(err) {
+				if err := m.cfg.Journal.Discard(txHash); err != nil {
+					log.Error(name+" unable to discard journaled "+
+						"transaction", "hash", txHash, "err", err)
+				}
+			}
 			if err == context.Canceled ||
 				strings.Contains(err.Error(), "context canceled") {
 				return
@@ -170,8 +372,17 @@ func (m *SimpleTxManager) Send(
 		log.Info(name+" transaction published successfully", "hash", txHash,
 			"nonce", nonce, "gasTipCap", gasTipCap, "gasFeeCap", gasFeeCap)
 
-		// Wait for the transaction to be mined, reporting the receipt
-		// back to the main event loop if found.
+		waitMinedAsync(tx)
+	}
+
+	// Create a closure that waits for a published transaction to be mined,
+	// reporting the receipt back to the main event loop if found.
+	waitMinedAsync = func(tx *types.Transaction) {
+		txHash := tx.Hash()
+		nonce := tx.Nonce()
+		gasTipCap := tx.GasTipCap()
+		gasFeeCap := tx.GasFeeCap()
+
 		receipt, err := waitMined(
 			ctxc, m.backend, tx, m.cfg.ReceiptQueryInterval,
 			m.cfg.NumConfirmations, sendState,
@@ -185,7 +396,7 @@ func (m *SimpleTxManager) Send(
 			// Use non-blocking select to ensure function can exit
 			// if more than one receipt is discovered.
 			select {
-			case receiptChan <- receipt:
+			case receiptChan <- minedTx{tx, receipt}:
 				log.Trace(name+" send tx succeeded", "hash", txHash,
 					"nonce", nonce, "gasTipCap", gasTipCap,
 					"gasFeeCap", gasFeeCap)
@@ -196,9 +407,19 @@ func (m *SimpleTxManager) Send(
 
 	// Submit and wait for the receipt at our first gas price in the
 	// background, before entering the event loop and waiting out the
-	// resubmission timeout.
-	wg.Add(1)
-	go sendTxAsync()
+	// resubmission timeout. A previously published tx is waited on instead.
+	if len(prev) > 0 {
+		for _, tx := range prev {
+			wg.Add(1)
+			go func(tx *types.Transaction) {
+				defer wg.Done()
+				waitMinedAsync(tx)
+			}(tx)
+		}
+	} else {
+		wg.Add(1)
+		go sendTxAsync()
+	}
 
 	for {
 		select {
@@ -223,13 +444,57 @@ func (m *SimpleTxManager) Send(
 		case <-ctxc.Done():
 			return nil, ctxc.Err()
 
+		// The fees required to publish the transaction exceed the maximum
+		// fees set by the operator, or the transaction is stale.
+		case err := <-abortChan:
+			return nil, err
+
 		// The transaction has confirmed.
-		case receipt := <-receiptChan:
+		case mined := <-receiptChan:
+			receipt := mined.receipt
+			if m.cfg.Journal != nil {
+				if err := m.cfg.Journal.Confirm(receipt.TxHash); err != nil {
+					log.Error(name+" unable to remove transaction from "+
+						"journal", "hash", receipt.TxHash, "err", err)
+				}
+			}
+			if receipt.Status == types.ReceiptStatusFailed {
+				return nil, m.revertError(ctx, mined.tx, receipt)
+			}
 			return receipt, nil
 		}
 	}
 }
 
+// minedTx is a mined transaction and its receipt.
+type minedTx struct {
+	tx      *types.Transaction
+	receipt *types.Receipt
+}
+
+// revertError creates the RevertError of a reverted transaction, with the
+// revert reason if the backend can replay the transaction.
+func (m *SimpleTxManager) revertError(
+	ctx context.Context,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) *RevertError {
+
+	revertErr := &RevertError{Receipt: receipt}
+
+	caller, ok := m.backend.(CallSource)
+	if !ok {
+		return revertErr
+	}
+	reason, err := RevertReason(ctx, caller, tx, receipt.BlockNumber)
+	if err != nil {
+		log.Warn(m.name+" unable to replay reverted transaction",
+			"hash", receipt.TxHash, "err", err)
+	}
+	revertErr.Reason = reason
+	return revertErr
+}
+
 // WaitMined blocks until the backend indicates confirmation of tx and returns
 // the tx receipt. Queries are made every queryInterval, regardless of whether
 // the backend returns an error. This method can be canceled using the passed
@@ -3,6 +3,7 @@ package txmgr_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"sync"
 	"testing"
@@ -98,6 +99,7 @@ func (g *gasPricer) sample() (*big.Int, *big.Int) {
 type minedTxInfo struct {
 	gasFeeCap   *big.Int
 	blockNumber uint64
+	status      uint64
 }
 
 // mockBackend implements txmgr.ReceiptSource that tracks mined transactions
@@ -123,6 +125,17 @@ func newMockBackend() *mockBackend {
 // TransactionReceipt with a matching txHash will result in a non-nil receipt.
 // If a nil txHash is supplied this has the effect of mining an empty block.
 func (b *mockBackend) mine(txHash *common.Hash, gasFeeCap *big.Int) {
+	b.mineWithStatus(txHash, gasFeeCap, types.ReceiptStatusSuccessful)
+}
+
+// mineReverted is like mine, but the transaction reverts.
+func (b *mockBackend) mineReverted(txHash *common.Hash, gasFeeCap *big.Int) {
+	b.mineWithStatus(txHash, gasFeeCap, types.ReceiptStatusFailed)
+}
+
+func (b *mockBackend) mineWithStatus(
+	txHash *common.Hash, gasFeeCap *big.Int, status uint64) {
+
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
@@ -131,6 +144,7 @@ func (b *mockBackend) mine(txHash *common.Hash, gasFeeCap *big.Int) {
 		b.minedTxs[*txHash] = minedTxInfo{
 			gasFeeCap:   gasFeeCap,
 			blockNumber: b.blockHeight,
+			status:      status,
 		}
 	}
 }
@@ -166,6 +180,7 @@ func (b *mockBackend) TransactionReceipt(
 		TxHash:      txHash,
 		GasUsed:     txInfo.gasFeeCap.Uint64(),
 		BlockNumber: big.NewInt(int64(txInfo.blockNumber)),
+		Status:      txInfo.status,
 	}, nil
 }
 
@@ -230,6 +245,74 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	require.Nil(t, receipt)
 }
 
+// TestTxMgrAbortsOnFeeCapExceeded asserts that Send gives up and returns the
+// error if the fees required to resubmit a tx exceed the fee cap.
+func TestTxMgrAbortsOnFeeCapExceeded(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+
+	var attempts int
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		attempts++
+		if attempts > 1 {
+			return nil, txmgr.ErrFeeCapExceeded
+		}
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		// Don't publish tx to backend, simulating never being mined.
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.Equal(t, txmgr.ErrFeeCapExceeded, err)
+	require.Nil(t, receipt)
+	require.Equal(t, 2, attempts)
+}
+
+// TestTxMgrAbortsOnStaleTx asserts that Send gives up instead of resubmitting
+// a tx that has become stale, such that the caller can craft it again.
+func TestTxMgrAbortsOnStaleTx(t *testing.T) {
+	t.Parallel()
+
+	h := newTestHarness()
+
+	var attempts int
+	updateGasPrice := func(ctx context.Context) (*types.Transaction, error) {
+		attempts++
+		if attempts > 1 {
+			return nil, fmt.Errorf("l1 block reorged: %w", txmgr.ErrTxStale)
+		}
+		gasTipCap, gasFeeCap := h.gasPricer.sample()
+		return types.NewTx(&types.DynamicFeeTx{
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+		}), nil
+	}
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		// Don't publish tx to backend, simulating never being mined.
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := h.mgr.Send(ctx, updateGasPrice, sendTx)
+	require.True(t, errors.Is(err, txmgr.ErrTxStale))
+	require.Nil(t, receipt)
+	require.Equal(t, 2, attempts)
+}
+
 // TestTxMgrConfirmsAtMaxGasPrice asserts that Send properly returns the max gas
 // price receipt if none of the lower gas price txs were mined.
 func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
@@ -0,0 +1,204 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+// OutputOracle is the read-only view of the L2OutputOracle contract that the
+// watcher checks the proposed outputs of.
+type OutputOracle interface {
+	StartingBlockTimestamp(opts *bind.CallOpts) (*big.Int, error)
+	LatestBlockTimestamp(opts *bind.CallOpts) (*big.Int, error)
+	SubmissionFrequency(opts *bind.CallOpts) (*big.Int, error)
+	ComputeL2BlockNumber(opts *bind.CallOpts, timestamp *big.Int) (*big.Int, error)
+	L2Outputs(opts *bind.CallOpts, timestamp *big.Int) ([32]byte, error)
+}
+
+// RollupClient is the honest rollup node that the expected outputs are
+// computed by.
+type RollupClient interface {
+	OutputAtBlock(ctx context.Context, number *big.Int) (version l2.Bytes32, root l2.Bytes32, err error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+type Config struct {
+	Name         string
+	Oracle       OutputOracle
+	RollupClient RollupClient
+	PollInterval time.Duration
+
+	// Registry is the metrics registry that the watcher metrics are
+	// registered in.
+	Registry metrics.Registry
+}
+
+// Mismatch is a proposed L2 output that does not match the output computed by
+// the rollup node.
+type Mismatch struct {
+	Timestamp *big.Int
+	L2Block   *big.Int
+	Proposed  l2.Bytes32
+	Expected  l2.Bytes32
+}
+
+// Watcher scans the outputs proposed to the L2OutputOracle, and checks every
+// output against the output that the rollup node computes for the same L2
+// block. Mismatches are logged as errors and counted in the metrics.
+//
+// Outputs are only checked once the L2 block is safe: the rollup node derived
+// the block from L1, so a mismatch cannot be caused by unsafe L2 blocks.
+type Watcher struct {
+	cfg    Config
+	ctx    context.Context
+	cancel func()
+
+	// nextTimestamp is the timestamp of the next output to check, nil until
+	// the first output to check is determined.
+	nextTimestamp *big.Int
+
+	checked       metrics.Counter
+	mismatched    metrics.Counter
+	latestChecked metrics.Gauge
+
+	wg sync.WaitGroup
+}
+
+func NewWatcher(ctx context.Context, cfg Config) *Watcher {
+	ctx, cancel := context.WithCancel(ctx)
+
+	latestChecked := new(metrics.StandardGauge)
+	_ = cfg.Registry.Register("l2os/watcher/outputs/latest_block", latestChecked)
+
+	return &Watcher{
+		cfg:           cfg,
+		ctx:           ctx,
+		cancel:        cancel,
+		checked:       metrics.NewRegisteredCounterForced("l2os/watcher/outputs/checked", cfg.Registry),
+		mismatched:    metrics.NewRegisteredCounterForced("l2os/watcher/outputs/mismatched", cfg.Registry),
+		latestChecked: latestChecked,
+	}
+}
+
+func (w *Watcher) Start() error {
+	w.wg.Add(1)
+	go w.eventLoop()
+	return nil
+}
+
+func (w *Watcher) Stop() error {
+	w.cancel()
+	w.wg.Wait()
+	return nil
+}
+
+func (w *Watcher) eventLoop() {
+	defer w.wg.Done()
+
+	name := w.cfg.Name
+
+	for {
+		select {
+		case <-time.After(w.cfg.PollInterval):
+			if _, err := w.CheckOutputs(w.ctx); err != nil {
+				log.Error(name+" unable to check proposed outputs", "err", err)
+			}
+
+		case <-w.ctx.Done():
+			log.Info(name + " watcher shutting down")
+			return
+		}
+	}
+}
+
+// CheckOutputs checks all proposed outputs that have not been checked yet, up
+// to the latest output of a safe L2 block, and returns the mismatches found.
+func (w *Watcher) CheckOutputs(ctx context.Context) ([]*Mismatch, error) {
+	name := w.cfg.Name
+
+	callOpts := &bind.CallOpts{
+		Pending: false,
+		Context: ctx,
+	}
+
+	frequency, err := w.cfg.Oracle.SubmissionFrequency(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	if frequency.Sign() <= 0 {
+		return nil, errors.New("oracle has no submission frequency")
+	}
+
+	// The genesis output is set when deploying the oracle, and is not
+	// computed by the rollup node. Start at the first proposed output.
+	if w.nextTimestamp == nil {
+		startingTimestamp, err := w.cfg.Oracle.StartingBlockTimestamp(callOpts)
+		if err != nil {
+			return nil, err
+		}
+		w.nextTimestamp = new(big.Int).Add(startingTimestamp, frequency)
+	}
+
+	latestTimestamp, err := w.cfg.Oracle.LatestBlockTimestamp(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	status, err := w.cfg.RollupClient.SyncStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mismatches []*Mismatch
+	for w.nextTimestamp.Cmp(latestTimestamp) <= 0 {
+		timestamp := w.nextTimestamp
+		l2Block, err := w.cfg.Oracle.ComputeL2BlockNumber(callOpts, timestamp)
+		if err != nil {
+			return mismatches, err
+		}
+		if l2Block.Uint64() > status.SafeL2.Number {
+			log.Info(name+" proposed output is not safe yet", "timestamp",
+				timestamp, "block", l2Block, "safeHead", status.SafeL2)
+			break
+		}
+
+		proposed, err := w.cfg.Oracle.L2Outputs(callOpts, timestamp)
+		if err != nil {
+			return mismatches, err
+		}
+		_, expected, err := w.cfg.RollupClient.OutputAtBlock(ctx, l2Block)
+		if err != nil {
+			return mismatches, err
+		}
+
+		w.checked.Inc(1)
+		w.latestChecked.Update(l2Block.Int64())
+		if l2.Bytes32(proposed) != expected {
+			w.mismatched.Inc(1)
+			log.Error(name+" proposed output does not match the rollup node",
+				"timestamp", timestamp, "block", l2Block,
+				"proposed", l2.Bytes32(proposed), "expected", expected)
+			mismatches = append(mismatches, &Mismatch{
+				Timestamp: timestamp,
+				L2Block:   l2Block,
+				Proposed:  proposed,
+				Expected:  expected,
+			})
+		} else {
+			log.Info(name+" proposed output is valid", "timestamp",
+				timestamp, "block", l2Block, "output", expected)
+		}
+
+		w.nextTimestamp = new(big.Int).Add(timestamp, frequency)
+	}
+
+	return mismatches, nil
+}
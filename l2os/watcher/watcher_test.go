@@ -0,0 +1,118 @@
+package watcher_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/l2os/watcher"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	startingTimestamp   = 1000
+	submissionFrequency = 10
+	l2BlockTime         = 2
+)
+
+// mockOracle mirrors the MockL2OutputOracle contract.
+type mockOracle struct {
+	outputs map[uint64][32]byte
+	latest  uint64
+}
+
+func newMockOracle() *mockOracle {
+	return &mockOracle{
+		outputs: map[uint64][32]byte{startingTimestamp: {}},
+		latest:  startingTimestamp,
+	}
+}
+
+func (o *mockOracle) propose(output l2.Bytes32) {
+	o.latest += submissionFrequency
+	o.outputs[o.latest] = output
+}
+
+func (o *mockOracle) StartingBlockTimestamp(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(startingTimestamp), nil
+}
+
+func (o *mockOracle) LatestBlockTimestamp(opts *bind.CallOpts) (*big.Int, error) {
+	return new(big.Int).SetUint64(o.latest), nil
+}
+
+func (o *mockOracle) SubmissionFrequency(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(submissionFrequency), nil
+}
+
+func (o *mockOracle) ComputeL2BlockNumber(opts *bind.CallOpts, timestamp *big.Int) (*big.Int, error) {
+	return new(big.Int).SetUint64((timestamp.Uint64() - startingTimestamp) / l2BlockTime), nil
+}
+
+func (o *mockOracle) L2Outputs(opts *bind.CallOpts, timestamp *big.Int) ([32]byte, error) {
+	return o.outputs[timestamp.Uint64()], nil
+}
+
+// mockRollupClient computes a fake output root for every L2 block.
+type mockRollupClient struct {
+	safeHead uint64
+}
+
+func outputAt(number uint64) l2.Bytes32 {
+	return l2.Bytes32{0: 0xaa, 31: byte(number)}
+}
+
+func (c *mockRollupClient) OutputAtBlock(ctx context.Context, number *big.Int) (l2.Bytes32, l2.Bytes32, error) {
+	return l2.OutputRootVersionV0, outputAt(number.Uint64()), nil
+}
+
+func (c *mockRollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return &eth.SyncStatus{SafeL2: eth.BlockID{Number: c.safeHead}}, nil
+}
+
+func TestCheckOutputs(t *testing.T) {
+	oracle := newMockOracle()
+	rollupClient := &mockRollupClient{safeHead: 10}
+	registry := metrics.NewRegistry()
+	w := watcher.NewWatcher(context.Background(), watcher.Config{
+		Name:         "TEST",
+		Oracle:       oracle,
+		RollupClient: rollupClient,
+		Registry:     registry,
+	})
+
+	// Outputs of L2 blocks 5, 10 and 15, the second one is invalid.
+	oracle.propose(outputAt(5))
+	oracle.propose(l2.Bytes32{0xff})
+	oracle.propose(outputAt(15))
+
+	mismatches, err := w.CheckOutputs(context.Background())
+	require.NoError(t, err)
+	require.Len(t, mismatches, 1)
+	require.Equal(t, uint64(10), mismatches[0].L2Block.Uint64())
+	require.Equal(t, uint64(startingTimestamp+2*submissionFrequency), mismatches[0].Timestamp.Uint64())
+	require.Equal(t, l2.Bytes32{0xff}, mismatches[0].Proposed)
+	require.Equal(t, outputAt(10), mismatches[0].Expected)
+
+	// Block 15 is not safe yet, and is checked once it is.
+	rollupClient.safeHead = 15
+	mismatches, err = w.CheckOutputs(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+
+	// Outputs are only checked once.
+	mismatches, err = w.CheckOutputs(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, mismatches)
+
+	checked := registry.Get("l2os/watcher/outputs/checked").(metrics.Counter)
+	require.Equal(t, int64(3), checked.Count())
+	mismatched := registry.Get("l2os/watcher/outputs/mismatched").(metrics.Counter)
+	require.Equal(t, int64(1), mismatched.Count())
+	latest := registry.Get("l2os/watcher/outputs/latest_block").(metrics.Gauge)
+	require.Equal(t, int64(15), latest.Value())
+}
@@ -0,0 +1,195 @@
+package balance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrInsufficientBalance is returned when the balance of a wallet is below the minimum balance,
+// and submissions from it are paused.
+var ErrInsufficientBalance = errors.New("insufficient balance")
+
+// Level classifies the balance of a wallet.
+type Level string
+
+const (
+	// LevelUnknown is the level of a wallet whose balance was not checked yet.
+	LevelUnknown Level = "unknown"
+	// LevelOK is the level of a wallet at or above all thresholds.
+	LevelOK Level = "ok"
+	// LevelLow is the level of a wallet below the warning threshold.
+	LevelLow Level = "low"
+	// LevelInsufficient is the level of a wallet below the minimum balance: submissions are paused.
+	LevelInsufficient Level = "insufficient"
+)
+
+// Config houses the balance thresholds of a wallet, in wei. A nil threshold is disabled.
+type Config struct {
+	// WarnThreshold is the balance below which a warning is logged on every check.
+	WarnThreshold *big.Int
+	// MinBalance is the balance below which submissions are paused, until the wallet is funded.
+	MinBalance *big.Int
+}
+
+// Check returns an error if the warning threshold is below the minimum balance.
+func (c Config) Check() error {
+	if c.WarnThreshold != nil && c.MinBalance != nil && c.WarnThreshold.Cmp(c.MinBalance) < 0 {
+		return fmt.Errorf("balance warning threshold %s is below the minimum balance %s", c.WarnThreshold, c.MinBalance)
+	}
+	return nil
+}
+
+// Source provides the balance of an account.
+//
+// NOTE: This is a subset of bind.ContractBackend.
+type Source interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+}
+
+// Status is the last checked balance of a wallet.
+type Status struct {
+	Address common.Address `json:"address"`
+	Balance *big.Int       `json:"balance"`
+	Level   Level          `json:"level"`
+}
+
+// Paused returns whether submissions from the wallet are paused.
+func (s Status) Paused() bool {
+	return s.Level == LevelInsufficient
+}
+
+// Monitor tracks the balance of a wallet against the thresholds of its config.
+type Monitor struct {
+	cfg    Config
+	source Source
+	log    log.Logger
+
+	mu     sync.Mutex
+	status Status
+
+	balanceGauge metrics.Gauge
+	pausedGauge  metrics.Gauge
+}
+
+func NewMonitor(addr common.Address, cfg Config, source Source, log log.Logger) *Monitor {
+	return &Monitor{
+		cfg:          cfg,
+		source:       source,
+		log:          log,
+		status:       Status{Address: addr, Level: LevelUnknown},
+		balanceGauge: metrics.NilGauge{},
+		pausedGauge:  metrics.NilGauge{},
+	}
+}
+
+// RegisterMetrics registers the gauges of the wallet in the registry: wallet/balance_gwei is the last checked balance
+// in gwei, and wallet/paused is 1 while submissions are paused. The gauges are updated on every check.
+// It must be called before the first check.
+func (m *Monitor) RegisterMetrics(registry metrics.Registry) {
+	m.balanceGauge = new(metrics.StandardGauge)
+	_ = registry.Register("wallet/balance_gwei", m.balanceGauge)
+	m.pausedGauge = new(metrics.StandardGauge)
+	_ = registry.Register("wallet/paused", m.pausedGauge)
+}
+
+// Check fetches the latest balance of the wallet and updates its status. It returns ErrInsufficientBalance if the
+// balance is below the minimum balance. If the balance cannot be fetched, the previous status is kept.
+func (m *Monitor) Check(ctx context.Context) error {
+	addr := m.Status().Address
+	balance, err := m.source.BalanceAt(ctx, addr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch balance of %s: %w", addr, err)
+	}
+
+	level := LevelOK
+	switch {
+	case m.cfg.MinBalance != nil && balance.Cmp(m.cfg.MinBalance) < 0:
+		level = LevelInsufficient
+		m.log.Error("Wallet balance below minimum balance, pausing submissions", "address", addr,
+			"balance", balance, "min_balance", m.cfg.MinBalance)
+	case m.cfg.WarnThreshold != nil && balance.Cmp(m.cfg.WarnThreshold) < 0:
+		level = LevelLow
+		m.log.Warn("Wallet balance below warning threshold", "address", addr,
+			"balance", balance, "warn_threshold", m.cfg.WarnThreshold)
+	}
+
+	m.mu.Lock()
+	if m.status.Paused() && level != LevelInsufficient {
+		m.log.Info("Wallet funded, resuming submissions", "address", addr, "balance", balance)
+	}
+	m.status.Balance = balance
+	m.status.Level = level
+	m.mu.Unlock()
+
+	m.balanceGauge.Update(new(big.Int).Div(balance, big.NewInt(params.GWei)).Int64())
+	if level == LevelInsufficient {
+		m.pausedGauge.Update(1)
+	} else {
+		m.pausedGauge.Update(0)
+	}
+
+	if level == LevelInsufficient {
+		return fmt.Errorf("%w: %s has %s wei, minimum is %s", ErrInsufficientBalance, addr, balance, m.cfg.MinBalance)
+	}
+	return nil
+}
+
+// Status returns the last checked status of the wallet.
+func (m *Monitor) Status() Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.status
+}
+
+// ParseEther parses a decimal amount of ether, e.g. "0.5", into wei. An empty amount is parsed as nil.
+func ParseEther(amount string) (*big.Int, error) {
+	if amount == "" {
+		return nil, nil
+	}
+	ether, ok := new(big.Rat).SetString(amount)
+	if !ok || ether.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount of ether: %q", amount)
+	}
+	wei := ether.Mul(ether, new(big.Rat).SetInt64(params.Ether))
+	if !wei.IsInt() {
+		return nil, fmt.Errorf("amount of ether has more than 18 decimals: %q", amount)
+	}
+	return wei.Num(), nil
+}
+
+// HealthResponse is the body served by the HealthHandler.
+type HealthResponse struct {
+	// Healthy is false if submissions from any of the wallets are paused.
+	Healthy bool              `json:"healthy"`
+	Wallets map[string]Status `json:"wallets"`
+}
+
+// HealthHandler serves the status of the wallets of the monitors, by name. It responds with 503 Service Unavailable
+// if submissions from any of the wallets are paused.
+func HealthHandler(monitors map[string]*Monitor) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res := HealthResponse{Healthy: true, Wallets: make(map[string]Status, len(monitors))}
+		for name, monitor := range monitors {
+			status := monitor.Status()
+			res.Wallets[name] = status
+			if status.Paused() {
+				res.Healthy = false
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !res.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	})
+}
@@ -0,0 +1,148 @@
+package balance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+type testSource struct {
+	balance *big.Int
+	err     error
+}
+
+func (s *testSource) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return s.balance, s.err
+}
+
+func ether(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(params.Ether))
+}
+
+func TestMonitorCheck(t *testing.T) {
+	addr := common.Address{0x01}
+	source := &testSource{balance: ether(10)}
+	m := NewMonitor(addr, Config{WarnThreshold: ether(5), MinBalance: ether(1)}, source, log.New())
+	require.Equal(t, LevelUnknown, m.Status().Level)
+
+	ctx := context.Background()
+	require.NoError(t, m.Check(ctx))
+	require.Equal(t, Status{Address: addr, Balance: ether(10), Level: LevelOK}, m.Status())
+
+	source.balance = ether(3)
+	require.NoError(t, m.Check(ctx))
+	require.Equal(t, LevelLow, m.Status().Level)
+	require.False(t, m.Status().Paused())
+
+	source.balance = big.NewInt(1)
+	err := m.Check(ctx)
+	require.True(t, errors.Is(err, ErrInsufficientBalance))
+	require.Equal(t, LevelInsufficient, m.Status().Level)
+	require.True(t, m.Status().Paused())
+
+	// A failed check keeps the previous status.
+	source.err = errors.New("connection refused")
+	require.Error(t, m.Check(ctx))
+	require.True(t, m.Status().Paused())
+
+	// Funding the wallet resumes submissions.
+	source.balance, source.err = ether(6), nil
+	require.NoError(t, m.Check(ctx))
+	require.Equal(t, LevelOK, m.Status().Level)
+}
+
+func TestMonitorWithoutThresholds(t *testing.T) {
+	m := NewMonitor(common.Address{0x01}, Config{}, &testSource{balance: big.NewInt(0)}, log.New())
+	require.NoError(t, m.Check(context.Background()))
+	require.Equal(t, LevelOK, m.Status().Level)
+}
+
+func TestMonitorMetrics(t *testing.T) {
+	source := &testSource{balance: ether(10)}
+	m := NewMonitor(common.Address{0x01}, Config{MinBalance: ether(1)}, source, log.New())
+	registry := metrics.NewRegistry()
+	m.RegisterMetrics(registry)
+	balanceGauge := registry.Get("wallet/balance_gwei").(metrics.Gauge)
+	pausedGauge := registry.Get("wallet/paused").(metrics.Gauge)
+
+	ctx := context.Background()
+	require.NoError(t, m.Check(ctx))
+	require.Equal(t, int64(10*params.GWei), balanceGauge.Value())
+	require.Equal(t, int64(0), pausedGauge.Value())
+
+	source.balance = big.NewInt(params.GWei / 2)
+	require.ErrorIs(t, m.Check(ctx), ErrInsufficientBalance)
+	require.Equal(t, int64(0), balanceGauge.Value())
+	require.Equal(t, int64(1), pausedGauge.Value())
+
+	// The gauges keep the last checked status if the balance cannot be fetched.
+	source.err = errors.New("unavailable")
+	require.Error(t, m.Check(ctx))
+	require.Equal(t, int64(1), pausedGauge.Value())
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, Config{}.Check())
+	require.NoError(t, Config{WarnThreshold: ether(1)}.Check())
+	require.NoError(t, Config{WarnThreshold: ether(1), MinBalance: ether(1)}.Check())
+	require.Error(t, Config{WarnThreshold: ether(1), MinBalance: ether(2)}.Check())
+}
+
+func TestParseEther(t *testing.T) {
+	wei, err := ParseEther("")
+	require.NoError(t, err)
+	require.Nil(t, wei)
+
+	wei, err = ParseEther("1.5")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(15e17), wei)
+
+	wei, err = ParseEther("0.000000000000000001")
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), wei)
+
+	_, err = ParseEther("0.0000000000000000001")
+	require.Error(t, err)
+	_, err = ParseEther("-1")
+	require.Error(t, err)
+	_, err = ParseEther("one")
+	require.Error(t, err)
+}
+
+func TestHealthHandler(t *testing.T) {
+	ctx := context.Background()
+	source := &testSource{balance: ether(2)}
+	m := NewMonitor(common.Address{0x01}, Config{MinBalance: ether(1)}, source, log.New())
+	require.NoError(t, m.Check(ctx))
+	handler := HealthHandler(map[string]*Monitor{"submitter": m})
+
+	serve := func() (int, HealthResponse) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+		var res HealthResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&res))
+		return rec.Code, res
+	}
+
+	code, res := serve()
+	require.Equal(t, http.StatusOK, code)
+	require.True(t, res.Healthy)
+	require.Equal(t, m.Status(), res.Wallets["submitter"])
+
+	source.balance = big.NewInt(0)
+	require.Error(t, m.Check(ctx))
+	code, res = serve()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, res.Healthy)
+	require.Equal(t, LevelInsufficient, res.Wallets["submitter"].Level)
+}
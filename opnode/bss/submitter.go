@@ -3,18 +3,18 @@ package bss
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"errors"
 	"math/big"
 	"time"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
@@ -22,7 +22,9 @@ type BatchSubmitter struct {
 	Client    *ethclient.Client
 	ToAddress common.Address
 	ChainID   *big.Int
-	PrivKey   *ecdsa.PrivateKey
+	Signer    signer.Signer
+	// Balance, if set, tracks the balance of the signer, and pauses submission while it is below the minimum balance
+	Balance *balance.Monitor
 }
 
 // func NewSubmitter(client ethclient.Client, addr common.Address) *BatchSubmitter {
@@ -35,12 +37,18 @@ func (b *BatchSubmitter) Submit(config *rollup.Config, batches []*derive.BatchDa
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 
+	if b.Balance != nil {
+		if err := b.Balance.Check(ctx); err != nil {
+			return common.Hash{}, err
+		}
+	}
+
 	var buf bytes.Buffer
 	if err := derive.EncodeBatches(config, batches, &buf); err != nil {
 		return common.Hash{}, err
 	}
 
-	addr := crypto.PubkeyToAddress(b.PrivKey.PublicKey)
+	addr := b.Signer.Address()
 	nonce, err := b.Client.PendingNonceAt(ctx, addr)
 	if err != nil {
 		return common.Hash{}, err
@@ -76,7 +84,7 @@ func (b *BatchSubmitter) Submit(config *rollup.Config, batches []*derive.BatchDa
 	}
 	rawTx.Gas = gas
 
-	tx, err := types.SignNewTx(b.PrivKey, types.LatestSignerForChainID(b.ChainID), rawTx)
+	tx, err := b.Signer.SignTx(ctx, b.ChainID, types.NewTx(rawTx))
 	if err != nil {
 		return common.Hash{}, err
 	}
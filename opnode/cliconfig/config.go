@@ -0,0 +1,256 @@
+// Package cliconfig loads the flags of a binary from a YAML config file.
+//
+// The keys of the file are flag names. Nested maps are joined with dots, such that
+//
+//	rpc:
+//	  addr: 0.0.0.0
+//
+// sets --rpc.addr. Flags that are set on the command line or in the environment take precedence over the file.
+package cliconfig
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// Errors collects independent errors, such that they can be reported at once.
+type Errors []error
+
+// Add appends the error, if any. The errors of nested Errors are appended individually.
+func (e *Errors) Add(err error) {
+	if err == nil {
+		return
+	}
+	if errs, ok := err.(Errors); ok {
+		*e = append(*e, errs...)
+		return
+	}
+	*e = append(*e, err)
+}
+
+// Err returns the collected errors, or nil if there are none.
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d errors: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// entry is the value of a flag in the config file.
+type entry struct {
+	line   int
+	values []string
+}
+
+// Load sets the flags that are not set on the command line or in the environment to their value in the config file
+// named by the config flag. Nothing is loaded if the config flag is not set. All unknown keys and invalid values are
+// reported at once.
+func Load(ctx *cli.Context, configFlag string) error {
+	path := ctx.GlobalString(configFlag)
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %v", err)
+	}
+	entries, err := parse(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode config file %s: %v", path, err)
+	}
+
+	known := make(map[string]bool)
+	for _, flag := range appFlags(ctx) {
+		eachName(flag, func(name string) { known[name] = true })
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs Errors
+	for _, name := range names {
+		e := entries[name]
+		if !known[name] || name == configFlag {
+			errs.Add(fmt.Errorf("%s:%d: unknown flag %q", path, e.line, name))
+			continue
+		}
+		if ctx.GlobalIsSet(name) {
+			continue
+		}
+		for _, value := range e.values {
+			if err := ctx.GlobalSet(name, value); err != nil {
+				errs.Add(fmt.Errorf("%s:%d: invalid value %q for flag %q: %v", path, e.line, value, name, err))
+			}
+		}
+	}
+	return errs.Err()
+}
+
+// parse flattens the YAML document into the values of every flag.
+func parse(data []byte) (map[string]entry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	entries := make(map[string]entry)
+	if len(doc.Content) == 0 { // empty file
+		return entries, nil
+	}
+	if err := flatten(doc.Content[0], "", entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func flatten(node *yaml.Node, prefix string, entries map[string]entry) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			name := key.Value
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if err := flatten(value, name, entries); err != nil {
+				return err
+			}
+		}
+		return nil
+	case yaml.SequenceNode:
+		e := entry{line: node.Line}
+		for _, item := range node.Content {
+			if item.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d: list %q must only contain values", item.Line, prefix)
+			}
+			e.values = append(e.values, item.Value)
+		}
+		return add(entries, prefix, e)
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil
+		}
+		return add(entries, prefix, entry{line: node.Line, values: []string{node.Value}})
+	default:
+		return fmt.Errorf("line %d: unexpected value for %q", node.Line, prefix)
+	}
+}
+
+func add(entries map[string]entry, name string, e entry) error {
+	if name == "" {
+		return fmt.Errorf("line %d: config file must be a map of flags", e.line)
+	}
+	if prev, ok := entries[name]; ok {
+		return fmt.Errorf("line %d: flag %q already set on line %d", e.line, name, prev.line)
+	}
+	entries[name] = e
+	return nil
+}
+
+// CheckRequired returns an error that lists all required flags that are not set on the command line, in the
+// environment or in the config file.
+func CheckRequired(ctx *cli.Context, required []cli.Flag) error {
+	var missing []string
+	for _, flag := range required {
+		name := flagName(flag)
+		if !ctx.GlobalIsSet(name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required flags: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// Redacted replaces the value of secret flags that are set, in the output of Dump.
+const Redacted = "<redacted>"
+
+// Dump writes the effective value of every flag, except the config flag, as a YAML config file.
+// The values of the secret flags, e.g. mnemonics and passwords, are replaced by Redacted.
+func Dump(ctx *cli.Context, w io.Writer, configFlag string, secretFlags []cli.Flag) error {
+	secrets := make(map[string]struct{}, len(secretFlags))
+	for _, flag := range secretFlags {
+		secrets[flagName(flag)] = struct{}{}
+	}
+	values := make(map[string]interface{})
+	for _, flag := range appFlags(ctx) {
+		name := flagName(flag)
+		if name == configFlag {
+			continue
+		}
+		if _, ok := secrets[name]; ok {
+			if ctx.GlobalIsSet(name) {
+				values[name] = Redacted
+			} else {
+				values[name] = ""
+			}
+			continue
+		}
+		switch flag.(type) {
+		case cli.StringSliceFlag:
+			values[name] = ctx.GlobalStringSlice(name)
+		case cli.BoolFlag:
+			values[name] = ctx.GlobalBool(name)
+		case cli.IntFlag:
+			values[name] = ctx.GlobalInt(name)
+		case cli.Uint64Flag:
+			values[name] = ctx.GlobalUint64(name)
+		case cli.DurationFlag:
+			values[name] = ctx.GlobalDuration(name).String()
+		default:
+			values[name] = ctx.GlobalString(name)
+		}
+	}
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(values); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// appFlags returns the flags of the app, without the help and version flags that the cli adds.
+func appFlags(ctx *cli.Context) []cli.Flag {
+	var flags []cli.Flag
+	for _, flag := range ctx.App.Flags {
+		if name := flagName(flag); name == flagName(cli.HelpFlag) || name == flagName(cli.VersionFlag) {
+			continue
+		}
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// flagName returns the long name of the flag.
+func flagName(flag cli.Flag) string {
+	return strings.TrimSpace(strings.Split(flag.GetName(), ",")[0])
+}
+
+func eachName(flag cli.Flag, fn func(name string)) {
+	for _, name := range strings.Split(flag.GetName(), ",") {
+		fn(strings.TrimSpace(name))
+	}
+}
@@ -0,0 +1,184 @@
+package cliconfig
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+var (
+	configFlag   = cli.StringFlag{Name: "config"}
+	addrFlag     = cli.StringFlag{Name: "rpc.addr", EnvVar: "CLICONFIG_TEST_RPC_ADDR"}
+	portFlag     = cli.IntFlag{Name: "rpc.port", Value: 8545}
+	engineFlag   = cli.StringSliceFlag{Name: "l2"}
+	intervalFlag = cli.DurationFlag{Name: "poll-interval"}
+	enabledFlag  = cli.BoolFlag{Name: "enabled"}
+	secretFlag   = cli.StringFlag{Name: "mnemonic", EnvVar: "CLICONFIG_TEST_MNEMONIC"}
+)
+
+// run runs an app with the test flags, that loads the config file in its Before func like the binaries.
+func run(t *testing.T, config string, args []string, action func(ctx *cli.Context) error) error {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0600))
+
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{configFlag, addrFlag, portFlag, engineFlag, intervalFlag, enabledFlag, secretFlag}
+	app.Before = func(ctx *cli.Context) error {
+		return Load(ctx, configFlag.Name)
+	}
+	app.Action = action
+	return app.Run(append([]string{"test", "--config", path}, args...))
+}
+
+func TestLoad(t *testing.T) {
+	config := `
+rpc:
+  addr: 0.0.0.0
+  port: 9545
+l2: [http://a, http://b]
+poll-interval: 5s
+enabled: true
+`
+	err := run(t, config, nil, func(ctx *cli.Context) error {
+		require.Equal(t, "0.0.0.0", ctx.GlobalString(addrFlag.Name))
+		require.Equal(t, 9545, ctx.GlobalInt(portFlag.Name))
+		require.Equal(t, []string{"http://a", "http://b"}, ctx.GlobalStringSlice(engineFlag.Name))
+		require.Equal(t, 5*time.Second, ctx.GlobalDuration(intervalFlag.Name))
+		require.True(t, ctx.GlobalBool(enabledFlag.Name))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	config := `
+rpc.addr: file
+rpc.port: 9545
+`
+	// The environment takes precedence over the file.
+	t.Setenv("CLICONFIG_TEST_RPC_ADDR", "env")
+	err := run(t, config, nil, func(ctx *cli.Context) error {
+		require.Equal(t, "env", ctx.GlobalString(addrFlag.Name))
+		require.Equal(t, 9545, ctx.GlobalInt(portFlag.Name))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Flags take precedence over the environment and the file.
+	err = run(t, config, []string{"--rpc.addr", "flag", "--rpc.port", "1"}, func(ctx *cli.Context) error {
+		require.Equal(t, "flag", ctx.GlobalString(addrFlag.Name))
+		require.Equal(t, 1, ctx.GlobalInt(portFlag.Name))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestLoadReportsAllErrors(t *testing.T) {
+	config := `
+rpc:
+  port: not-a-port
+unknown: 1
+poll-interval: 5
+config: other.yaml
+`
+	err := run(t, config, nil, func(ctx *cli.Context) error {
+		t.Fatal("action must not run")
+		return nil
+	})
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 4)
+	require.Contains(t, err.Error(), `unknown flag "config"`)
+	require.Contains(t, err.Error(), `invalid value "5" for flag "poll-interval"`)
+	require.Contains(t, err.Error(), `invalid value "not-a-port" for flag "rpc.port"`)
+	require.Contains(t, err.Error(), `unknown flag "unknown"`)
+}
+
+func TestLoadDuplicateFlag(t *testing.T) {
+	config := `
+rpc:
+  addr: a
+rpc.addr: b
+`
+	err := run(t, config, nil, func(ctx *cli.Context) error { return nil })
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `flag "rpc.addr" already set`)
+}
+
+func TestCheckRequired(t *testing.T) {
+	err := run(t, "rpc.addr: file", nil, func(ctx *cli.Context) error {
+		require.NoError(t, CheckRequired(ctx, []cli.Flag{addrFlag}))
+		err := CheckRequired(ctx, []cli.Flag{addrFlag, engineFlag, intervalFlag})
+		require.EqualError(t, err, "missing required flags: l2, poll-interval")
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDumpRoundTrip(t *testing.T) {
+	config := `
+rpc:
+  addr: 0.0.0.0
+l2: [http://a]
+poll-interval: 1m30s
+`
+	var dump bytes.Buffer
+	err := run(t, config, []string{"--enabled"}, func(ctx *cli.Context) error {
+		return Dump(ctx, &dump, configFlag.Name, nil)
+	})
+	require.NoError(t, err)
+	require.NotContains(t, dump.String(), "config")
+	require.NotContains(t, dump.String(), "version")
+
+	// The dump loads into the same effective config.
+	err = run(t, dump.String(), nil, func(ctx *cli.Context) error {
+		require.Equal(t, "0.0.0.0", ctx.GlobalString(addrFlag.Name))
+		require.Equal(t, 8545, ctx.GlobalInt(portFlag.Name))
+		require.Equal(t, []string{"http://a"}, ctx.GlobalStringSlice(engineFlag.Name))
+		require.Equal(t, 90*time.Second, ctx.GlobalDuration(intervalFlag.Name))
+		require.True(t, ctx.GlobalBool(enabledFlag.Name))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestDumpRedactsSecrets(t *testing.T) {
+	const mnemonic = "test test test test test test test test test test test junk"
+	for name, tc := range map[string]struct {
+		config string
+		args   []string
+		env    string
+	}{
+		"flag":   {args: []string{"--mnemonic", mnemonic}},
+		"env":    {env: mnemonic},
+		"config": {config: "mnemonic: " + mnemonic},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.env != "" {
+				t.Setenv(secretFlag.EnvVar, tc.env)
+			}
+			var dump bytes.Buffer
+			err := run(t, tc.config, tc.args, func(ctx *cli.Context) error {
+				require.Equal(t, mnemonic, ctx.GlobalString(secretFlag.Name))
+				return Dump(ctx, &dump, configFlag.Name, []cli.Flag{secretFlag})
+			})
+			require.NoError(t, err)
+			require.NotContains(t, dump.String(), "junk")
+			require.Contains(t, dump.String(), "mnemonic: "+Redacted)
+		})
+	}
+
+	t.Run("unset", func(t *testing.T) {
+		var dump bytes.Buffer
+		err := run(t, "", nil, func(ctx *cli.Context) error {
+			return Dump(ctx, &dump, configFlag.Name, []cli.Flag{secretFlag})
+		})
+		require.NoError(t, err)
+		require.NotContains(t, dump.String(), Redacted)
+	})
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RollupClient is a client of the "optimism" RPC namespace of the rollup node
+type RollupClient struct {
+	rpc *rpc.Client
+}
+
+func NewRollupClient(rpc *rpc.Client) *RollupClient {
+	return &RollupClient{rpc: rpc}
+}
+
+// OutputAtBlock returns the version and L2 output root of the given L2 block, as computed by the rollup node
+func (r *RollupClient) OutputAtBlock(ctx context.Context, number *big.Int) (version l2.Bytes32, root l2.Bytes32, err error) {
+	var output []l2.Bytes32
+	if err := r.rpc.CallContext(ctx, &output, "optimism_outputAtBlock", hexutil.Uint64(number.Uint64())); err != nil {
+		return l2.Bytes32{}, l2.Bytes32{}, err
+	}
+	if len(output) != 2 {
+		return l2.Bytes32{}, l2.Bytes32{}, fmt.Errorf("expected version and output root, got %d values", len(output))
+	}
+	return output[0], output[1], nil
+}
+
+// SyncStatus returns the L1 and L2 heads of the rollup node
+func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	var status *eth.SyncStatus
+	if err := r.rpc.CallContext(ctx, &status, "optimism_syncStatus"); err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return nil, errors.New("rollup node returned no sync status")
+	}
+	return status, nil
+}
+
+func (r *RollupClient) Close() {
+	r.rpc.Close()
+}
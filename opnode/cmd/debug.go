@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/flags"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli"
+)
+
+var debugCommand = cli.Command{
+	Name:  "debug",
+	Usage: "Inspect the derivation of the rollup",
+	Subcommands: []cli.Command{
+		{
+			Name:  "batches",
+			Usage: "Explain the batches of a range of epochs",
+			Description: "Re-runs the batch extraction and filtering of the derivation for every epoch in --l1-range, " +
+				"with the L1 node and rollup config of the global flags. Prints every candidate batch with the reason " +
+				"it was rejected, and the batch of every L2 block of the epoch. The L2 chain is assumed to be derived " +
+				"from all epochs before the range. Epochs of which the sequencing window extends past the L1 head " +
+				"are listed as incomplete.",
+			Flags:  flags.DebugBatchesFlags,
+			Action: DebugBatchesMain,
+		},
+	},
+}
+
+func DebugBatchesMain(ctx *cli.Context) error {
+	start, end, err := parseL1Range(ctx.String(flags.DebugL1RangeFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", flags.DebugL1RangeFlag.Name, err)
+	}
+	config, err := opnode.NewRollupConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if start <= config.Genesis.L1.Number {
+		return fmt.Errorf("the first epoch is L1 block %d, after the genesis L1 block", config.Genesis.L1.Number+1)
+	}
+
+	rpcCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	client, err := ethclient.DialContext(rpcCtx, ctx.GlobalString(flags.L1NodeAddr.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer client.Close()
+
+	prev, err := client.HeaderByNumber(rpcCtx, new(big.Int).SetUint64(start-1))
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 block %d: %w", start-1, err)
+	}
+	// the sequencing window of the last epoch ends SeqWindowSize-1 blocks after it,
+	// the windows of the last epochs may not be complete yet if that is past the L1 head
+	var inputs []*derive.L1Input
+	for num := start; num < end+config.SeqWindowSize; num++ {
+		block, err := client.BlockByNumber(rpcCtx, new(big.Int).SetUint64(num))
+		if errors.Is(err, ethereum.NotFound) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to fetch L1 block %d of the sequencing windows: %w", num, err)
+		}
+		inputs = append(inputs, &derive.L1Input{Info: block, Transactions: block.Transactions()})
+	}
+
+	w := ctx.App.Writer
+	parent := estimateParent(config, prev.Time)
+	traversal := derive.NewL1InputTraversal(inputs, config.SeqWindowSize)
+	for num := start; num <= end; num++ {
+		window, err := traversal.NextWindow(rpcCtx)
+		if errors.Is(err, io.EOF) {
+			fmt.Fprintf(w, "epochs %d..%d: incomplete sequencing windows, the L1 chain ends at block %d\n",
+				num, end, start+uint64(len(inputs))-1)
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read sequencing window of epoch %d: %w", num, err)
+		}
+		report := derive.ReportBatches(config, parent, window)
+		printBatchReport(w, report)
+		if n := len(report.Plan); n > 0 {
+			parent = parent.Next(report.Epoch, report.Plan[n-1].Timestamp)
+		}
+	}
+	return nil
+}
+
+// parseL1Range parses an inclusive range of L1 block numbers: A..B
+func parseL1Range(value string) (start uint64, end uint64, err error) {
+	parts := strings.Split(value, "..")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected A..B, got %q", value)
+	}
+	if start, err = strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid start: %w", err)
+	}
+	if end, err = strconv.ParseUint(parts[1], 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("invalid end: %w", err)
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("start %d is after end %d", start, end)
+	}
+	return start, end, nil
+}
+
+// estimateParent returns the L2 parent of the first epoch of the range, assuming the L2 chain is derived from all
+// previous epochs: every epoch ends with the last L2 block before its L1 time, so the parent is the last L2 block
+// before the time of the previous L1 block.
+func estimateParent(config *rollup.Config, prevL1Time uint64) derive.L2Parent {
+	t := config.Genesis.L2Time
+	if prevL1Time > t {
+		t += (prevL1Time - t - 1) / config.BlockTime * config.BlockTime
+	}
+	return derive.L2Parent{Time: t}
+}
+
+func printBatchReport(w io.Writer, report *derive.EpochBatchReport) {
+	fmt.Fprintf(w, "epoch %s: L2 blocks with timestamp in [%d, %d)\n", report.Epoch, report.MinL2Time, report.MaxL2Time)
+	sources := make(map[*derive.BatchData]*derive.BatchCandidate)
+	for _, c := range report.Candidates {
+		fmt.Fprintf(w, "  L1 block %d, tx %s", c.L1Block, c.TxHash)
+		if c.Sender != (common.Address{}) {
+			fmt.Fprintf(w, " from %s", c.Sender)
+		}
+		if c.Batch != nil {
+			sources[c.Batch] = c
+			fmt.Fprintf(w, ", batch %d: epoch %d, timestamp %d, version %d, %d txs", c.Index, c.Batch.Epoch,
+				c.Batch.Timestamp, c.Batch.Version, len(c.Batch.Transactions))
+		}
+		if c.Err != nil {
+			fmt.Fprintf(w, ": rejected: %v\n", c.Err)
+		} else {
+			fmt.Fprintf(w, ": accepted\n")
+		}
+	}
+	fmt.Fprintf(w, "  plan:\n")
+	for _, batch := range report.Plan {
+		if c, ok := sources[batch]; ok {
+			fmt.Fprintf(w, "    L2 block at %d: batch %d of tx %s, %d txs\n", batch.Timestamp, c.Index, c.TxHash,
+				len(batch.Transactions))
+		} else {
+			fmt.Fprintf(w, "    L2 block at %d: empty\n", batch.Timestamp)
+		}
+	}
+}
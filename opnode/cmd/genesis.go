@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/flags"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/genesis"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+)
+
+var genesisCommand = cli.Command{
+	Name:  "genesis",
+	Usage: "Generate a rollup config from the L1 chain and the L2 genesis",
+	Description: "Adds the predeploys to the L2 genesis (and to the L1 genesis of a new L1 chain), and writes the " +
+		"rollup config of a rollup that starts after the L1 block, from the resulting L2 genesis block.",
+	Flags:  flags.GenesisFlags,
+	Action: GenesisMain,
+}
+
+func GenesisMain(ctx *cli.Context) error {
+	params, err := genesisParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	l1RPC, l1File := ctx.String(flags.GenesisL1RPCFlag.Name), ctx.String(flags.GenesisL1FileFlag.Name)
+	var l1Start *types.Header
+	var l1ChainID *big.Int
+	switch {
+	case l1RPC != "" && l1File != "":
+		return fmt.Errorf("cannot use both --%s and --%s", flags.GenesisL1RPCFlag.Name, flags.GenesisL1FileFlag.Name)
+	case l1RPC != "":
+		l1Start, l1ChainID, err = l1StartFromRPC(ctx, l1RPC)
+	case l1File != "":
+		l1Start, l1ChainID, err = l1StartFromGenesis(ctx, l1File)
+	default:
+		return fmt.Errorf("missing L1: set --%s or --%s", flags.GenesisL1RPCFlag.Name, flags.GenesisL1FileFlag.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	l2Path, l2Out := ctx.String(flags.GenesisL2FileFlag.Name), ctx.String(flags.GenesisL2OutFlag.Name)
+	if l2Path == "" || l2Out == "" {
+		return fmt.Errorf("--%s and --%s are required", flags.GenesisL2FileFlag.Name, flags.GenesisL2OutFlag.Name)
+	}
+	l2Genesis, err := readGenesis(l2Path)
+	if err != nil {
+		return err
+	}
+	if err := genesis.AddL2Predeploys(l2Genesis); err != nil {
+		return fmt.Errorf("invalid L2 genesis: %w", err)
+	}
+	rollupConfig, err := genesis.RollupConfig(l1Start, l1ChainID, l2Genesis, params)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(l2Out, l2Genesis); err != nil {
+		return err
+	}
+	log.Info("Wrote L2 genesis", "path", l2Out, "hash", rollupConfig.Genesis.L2.Hash)
+
+	out := ctx.String(flags.GenesisOutFlag.Name)
+	if out == "" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rollupConfig)
+	}
+	if err := writeJSON(out, rollupConfig); err != nil {
+		return err
+	}
+	log.Info("Wrote rollup config", "path", out)
+	return nil
+}
+
+func genesisParams(ctx *cli.Context) (genesis.Params, error) {
+	params := genesis.Params{
+		BlockTime:            ctx.Uint64(flags.GenesisBlockTimeFlag.Name),
+		MaxSequencerTimeDiff: ctx.Uint64(flags.GenesisMaxSequencerTimeDiffFlag.Name),
+		SeqWindowSize:        ctx.Uint64(flags.GenesisSeqWindowSizeFlag.Name),
+		L1FeeOverhead:        ctx.Uint64(flags.GenesisL1FeeOverheadFlag.Name),
+		L1FeeScalar:          ctx.Uint64(flags.GenesisL1FeeScalarFlag.Name),
+	}
+	for _, addr := range []struct {
+		flag cli.StringFlag
+		dest *common.Address
+	}{
+		{flags.GenesisFeeRecipientFlag, &params.FeeRecipientAddress},
+		{flags.GenesisBatchInboxFlag, &params.BatchInboxAddress},
+		{flags.GenesisBatchSenderFlag, &params.BatchSenderAddress},
+	} {
+		value := ctx.String(addr.flag.Name)
+		if !common.IsHexAddress(value) {
+			return params, fmt.Errorf("invalid --%s address: %q", addr.flag.Name, value)
+		}
+		*addr.dest = common.HexToAddress(value)
+	}
+	return params, nil
+}
+
+// l1StartFromRPC fetches the L1 start block of a rollup on an existing L1 chain, where the deposit contract must
+// already be deployed.
+func l1StartFromRPC(ctx *cli.Context, addr string) (*types.Header, *big.Int, error) {
+	rpcCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	client, err := ethclient.DialContext(rpcCtx, addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer client.Close()
+
+	var number *big.Int
+	if ctx.IsSet(flags.GenesisL1BlockFlag.Name) {
+		number = new(big.Int).SetUint64(ctx.Uint64(flags.GenesisL1BlockFlag.Name))
+	}
+	header, err := client.HeaderByNumber(rpcCtx, number)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch L1 start block: %w", err)
+	}
+	chainID, err := client.ChainID(rpcCtx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch L1 chain id: %w", err)
+	}
+	code, err := client.CodeAt(rpcCtx, derive.DepositContractAddr, header.Number)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch deposit contract code: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, nil, fmt.Errorf("deposit contract is not deployed at %s in L1 block %d",
+			derive.DepositContractAddr, header.Number)
+	}
+	return header, chainID, nil
+}
+
+// l1StartFromGenesis adds the deposit contract to the genesis of a new L1 chain, and starts the rollup after its
+// genesis block.
+func l1StartFromGenesis(ctx *cli.Context, path string) (*types.Header, *big.Int, error) {
+	if ctx.IsSet(flags.GenesisL1BlockFlag.Name) {
+		return nil, nil, fmt.Errorf("--%s requires --%s", flags.GenesisL1BlockFlag.Name, flags.GenesisL1RPCFlag.Name)
+	}
+	out := ctx.String(flags.GenesisL1OutFlag.Name)
+	if out == "" {
+		return nil, nil, fmt.Errorf("--%s requires --%s", flags.GenesisL1FileFlag.Name, flags.GenesisL1OutFlag.Name)
+	}
+	l1Genesis, err := readGenesis(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if l1Genesis.Config == nil || l1Genesis.Config.ChainID == nil {
+		return nil, nil, errors.New("l1 genesis has no chain id")
+	}
+	if err := genesis.AddL1Predeploys(l1Genesis); err != nil {
+		return nil, nil, fmt.Errorf("invalid L1 genesis: %w", err)
+	}
+	header := l1Genesis.ToBlock(nil).Header()
+	if err := writeJSON(out, l1Genesis); err != nil {
+		return nil, nil, err
+	}
+	log.Info("Wrote L1 genesis", "path", out, "hash", header.Hash())
+	return header, l1Genesis.Config.ChainID, nil
+}
+
+func readGenesis(path string) (*core.Genesis, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read genesis: %w", err)
+	}
+	var g core.Genesis
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("failed to decode genesis %s: %w", path, err)
+	}
+	return &g, nil
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
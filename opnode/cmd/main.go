@@ -7,6 +7,7 @@ import (
 	"syscall"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/cliconfig"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/flags"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/node"
@@ -47,7 +48,19 @@ func main() {
 	app.Usage = "Optimism Rollup Node"
 	app.Description = "The deposit only rollup node drives the L2 execution engine based on L1 deposits."
 
+	app.Before = func(ctx *cli.Context) error {
+		return cliconfig.Load(ctx, flags.ConfigFlag.Name)
+	}
 	app.Action = RollupNodeMain
+	app.Commands = []cli.Command{
+		{
+			Name:   "dumpconfig",
+			Usage:  "Print the effective config, as a config file, and check it",
+			Action: DumpConfigMain,
+		},
+		genesisCommand,
+		debugCommand,
+	}
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
@@ -94,3 +107,17 @@ func RollupNodeMain(ctx *cli.Context) error {
 	return nil
 
 }
+
+// DumpConfigMain prints the effective flag values, loaded from the flags, environment variables and config file, and
+// reports all errors of the config at once.
+func DumpConfigMain(ctx *cli.Context) error {
+	if err := cliconfig.Dump(ctx, os.Stdout, flags.ConfigFlag.Name, flags.SecretFlags); err != nil {
+		return err
+	}
+	var errs cliconfig.Errors
+	_, err := opnode.NewConfig(ctx)
+	errs.Add(err)
+	_, err = opnode.NewLogConfig(ctx)
+	errs.Add(err)
+	return errs.Err()
+}
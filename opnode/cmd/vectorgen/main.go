@@ -0,0 +1,106 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/flags"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli"
+)
+
+var (
+	L1ToFlag = cli.Uint64Flag{
+		Name:  "l1.to",
+		Usage: "Last L1 block number to record (inclusive), defaults to the latest L1 block",
+	}
+	NameFlag = cli.StringFlag{
+		Name:  "name",
+		Usage: "Name of the test vector",
+		Value: "recorded",
+	}
+	OutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output file of the test vector, defaults to stdout",
+	}
+)
+
+func main() {
+	log.Root().SetHandler(
+		log.LvlFilterHandler(
+			log.LvlInfo,
+			log.StreamHandler(os.Stderr, log.TerminalFormat(true)),
+		),
+	)
+
+	app := cli.NewApp()
+	app.Flags = []cli.Flag{
+		flags.L1NodeAddr,
+		flags.RollupConfig,
+		flags.NetworkFlag,
+		L1ToFlag,
+		NameFlag,
+		OutFlag,
+	}
+	app.Name = "vectorgen"
+	app.Usage = "Derivation test vector generator"
+	app.Description = "Records the L1 chain from the rollup genesis onwards, and the payload attributes derived from it, as a test vector."
+	app.Action = VectorGenMain
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+func VectorGenMain(ctx *cli.Context) error {
+	rollupCfg, err := opnode.NewRollupConfig(ctx)
+	if err != nil {
+		return err
+	}
+	bgCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	client, err := ethclient.DialContext(bgCtx, ctx.GlobalString(flags.L1NodeAddr.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer client.Close()
+
+	to := ctx.GlobalUint64(L1ToFlag.Name)
+	if !ctx.GlobalIsSet(L1ToFlag.Name) {
+		head, err := client.HeaderByNumber(bgCtx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 head: %w", err)
+		}
+		to = head.Number.Uint64()
+	}
+	// Test vectors start from the rollup genesis, the L2 state beyond genesis is not recorded.
+	from := rollupCfg.Genesis.L1.Number + 1
+	parent := derive.L2Parent{Time: rollupCfg.Genesis.L2Time, L1Origin: rollupCfg.Genesis.L1}
+	log.Info("Recording test vector", "from", from, "to", to)
+	vector, err := derive.RecordTestVector(bgCtx, ctx.GlobalString(NameFlag.Name), rollupCfg, parent, client, from, to)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := ctx.GlobalString(OutFlag.Name); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+	}
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(vector); err != nil {
+		return fmt.Errorf("failed to write test vector: %w", err)
+	}
+	log.Info("Recorded test vector", "epochs", len(vector.Expected))
+	return nil
+}
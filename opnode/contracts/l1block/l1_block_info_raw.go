@@ -30,8 +30,8 @@ var (
 
 // L1blockMetaData contains all meta data concerning the L1block contract.
 var L1blockMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"OnlyDepositor\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"DEPOSITOR_ACCOUNT\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"basefee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"hash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"number\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_number\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_timestamp\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_basefee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_hash\",\"type\":\"bytes32\"}],\"name\":\"setL1BlockValues\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"timestamp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
-	Bin: "0x608060405234801561001057600080fd5b506103a2806100206000396000f3fe608060405234801561001057600080fd5b50600436106100625760003560e01c806309bd5a60146100675780635cf24969146100855780638381f58a146100a3578063b80777ea146100c1578063c03ba43e146100df578063e591b282146100fb575b600080fd5b61006f610119565b60405161007c91906101fd565b60405180910390f35b61008d61011f565b60405161009a9190610231565b60405180910390f35b6100ab610125565b6040516100b89190610231565b60405180910390f35b6100c961012b565b6040516100d69190610231565b60405180910390f35b6100f960048036038101906100f491906102a9565b610131565b005b6101036101cc565b6040516101109190610351565b60405180910390f35b60035481565b60025481565b60005481565b60015481565b73deaddeaddeaddeaddeaddeaddeaddeaddead000173ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146101aa576040517fce8c104800000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b8360008190555082600181905550816002819055508060038190555050505050565b73deaddeaddeaddeaddeaddeaddeaddeaddead000181565b6000819050919050565b6101f7816101e4565b82525050565b600060208201905061021260008301846101ee565b92915050565b6000819050919050565b61022b81610218565b82525050565b60006020820190506102466000830184610222565b92915050565b600080fd5b61025a81610218565b811461026557600080fd5b50565b60008135905061027781610251565b92915050565b610286816101e4565b811461029157600080fd5b50565b6000813590506102a38161027d565b92915050565b600080600080608085870312156102c3576102c261024c565b5b60006102d187828801610268565b94505060206102e287828801610268565b93505060406102f387828801610268565b925050606061030487828801610294565b91505092959194509250565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061033b82610310565b9050919050565b61034b81610330565b82525050565b60006020820190506103666000830184610342565b9291505056fea2646970667358221220cdaea4f1ee477c3fe7f1b3caa2283ad58d53d6a652006ec9e6f6bf22354b258264736f6c634300080a0033",
+	ABI: "[{\"inputs\":[],\"name\":\"OnlyDepositor\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"DEPOSITOR_ACCOUNT\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"basefee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"batcher\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"hash\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l1FeeOverhead\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"l1FeeScalar\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"number\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"sequenceNumber\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"\",\"type\":\"uint64\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_number\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_timestamp\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_basefee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_hash\",\"type\":\"bytes32\"}],\"name\":\"setL1BlockValues\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint64\",\"name\":\"_number\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"_timestamp\",\"type\":\"uint64\"},{\"internalType\":\"uint256\",\"name\":\"_basefee\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_hash\",\"type\":\"bytes32\"},{\"internalType\":\"uint64\",\"name\":\"_sequenceNumber\",\"type\":\"uint64\"},{\"internalType\":\"address\",\"name\":\"_batcher\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_l1FeeOverhead\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_l1FeeScalar\",\"type\":\"uint256\"}],\"name\":\"setL1BlockValuesV1\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"timestamp\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b50610740806100206000396000f3fe608060405234801561001057600080fd5b50600436106100a95760003560e01c80638381f58a116100715780638381f58a146101425780638b239f73146101605780639e8c49661461017e578063b80777ea1461019c578063c03ba43e146101ba578063e591b282146101d6576100a9565b8063025a3a29146100ae57806309bd5a60146100cc5780632fea6780146100ea5780635cf249691461010657806364ca23ef14610124575b600080fd5b6100b66101f4565b6040516100c39190610477565b60405180910390f35b6100d461021a565b6040516100e191906104ab565b60405180910390f35b61010460048036038101906100ff9190610599565b610220565b005b61010e61034b565b60405161011b919061065e565b60405180910390f35b61012c610351565b6040516101399190610688565b60405180910390f35b61014a61036b565b604051610157919061065e565b60405180910390f35b610168610371565b604051610175919061065e565b60405180910390f35b610186610377565b604051610193919061065e565b60405180910390f35b6101a461037d565b6040516101b1919061065e565b60405180910390f35b6101d460048036038101906101cf91906106a3565b610383565b005b6101de61041e565b6040516101eb9190610477565b60405180910390f35b600460089054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60035481565b73deaddeaddeaddeaddeaddeaddeaddeaddead000173ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614610299576040517fce8c104800000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b8767ffffffffffffffff166000819055508667ffffffffffffffff16600181905550856002819055508460038190555083600460006101000a81548167ffffffffffffffff021916908367ffffffffffffffff16021790555082600460086101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555081600581905550806006819055505050505050505050565b60025481565b600460009054906101000a900467ffffffffffffffff1681565b60005481565b60055481565b60065481565b60015481565b73deaddeaddeaddeaddeaddeaddeaddeaddead000173ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16146103fc576040517fce8c104800000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b8360008190555082600181905550816002819055508060038190555050505050565b73deaddeaddeaddeaddeaddeaddeaddeaddead000181565b600073ffffffffffffffffffffffffffffffffffffffff82169050919050565b600061046182610436565b9050919050565b61047181610456565b82525050565b600060208201905061048c6000830184610468565b92915050565b6000819050919050565b6104a581610492565b82525050565b60006020820190506104c0600083018461049c565b92915050565b600080fd5b600067ffffffffffffffff82169050919050565b6104e8816104cb565b81146104f357600080fd5b50565b600081359050610505816104df565b92915050565b6000819050919050565b61051e8161050b565b811461052957600080fd5b50565b60008135905061053b81610515565b92915050565b61054a81610492565b811461055557600080fd5b50565b60008135905061056781610541565b92915050565b61057681610456565b811461058157600080fd5b50565b6000813590506105938161056d565b92915050565b600080600080600080600080610100898b0312156105ba576105b96104c6565b5b60006105c88b828c016104f6565b98505060206105d98b828c016104f6565b97505060406105ea8b828c0161052c565b96505060606105fb8b828c01610558565b955050608061060c8b828c016104f6565b94505060a061061d8b828c01610584565b93505060c061062e8b828c0161052c565b92505060e061063f8b828c0161052c565b9150509295985092959890939650565b6106588161050b565b82525050565b6000602082019050610673600083018461064f565b92915050565b610682816104cb565b82525050565b600060208201905061069d6000830184610679565b92915050565b600080600080608085870312156106bd576106bc6104c6565b5b60006106cb8782880161052c565b94505060206106dc8782880161052c565b93505060406106ed8782880161052c565b92505060606106fe87828801610558565b9150509295919450925056fea2646970667358221220465390d552df4388de5c44e0cb409fb780a8a2ab95125812fc284844ec2bf3b164736f6c63430008150033",
 }
 
 // L1blockABI is the input ABI used to generate the binding from.
@@ -263,6 +263,37 @@ func (_L1block *L1blockCallerSession) Basefee() (*big.Int, error) {
 	return _L1block.Contract.Basefee(&_L1block.CallOpts)
 }
 
+// Batcher is a free data retrieval call binding the contract method 0x025a3a29.
+//
+// Solidity: function batcher() view returns(address)
+func (_L1block *L1blockCaller) Batcher(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _L1block.contract.Call(opts, &out, "batcher")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Batcher is a free data retrieval call binding the contract method 0x025a3a29.
+//
+// Solidity: function batcher() view returns(address)
+func (_L1block *L1blockSession) Batcher() (common.Address, error) {
+	return _L1block.Contract.Batcher(&_L1block.CallOpts)
+}
+
+// Batcher is a free data retrieval call binding the contract method 0x025a3a29.
+//
+// Solidity: function batcher() view returns(address)
+func (_L1block *L1blockCallerSession) Batcher() (common.Address, error) {
+	return _L1block.Contract.Batcher(&_L1block.CallOpts)
+}
+
 // Hash is a free data retrieval call binding the contract method 0x09bd5a60.
 //
 // Solidity: function hash() view returns(bytes32)
@@ -294,6 +325,68 @@ func (_L1block *L1blockCallerSession) Hash() ([32]byte, error) {
 	return _L1block.Contract.Hash(&_L1block.CallOpts)
 }
 
+// L1FeeOverhead is a free data retrieval call binding the contract method 0x8b239f73.
+//
+// Solidity: function l1FeeOverhead() view returns(uint256)
+func (_L1block *L1blockCaller) L1FeeOverhead(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _L1block.contract.Call(opts, &out, "l1FeeOverhead")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L1FeeOverhead is a free data retrieval call binding the contract method 0x8b239f73.
+//
+// Solidity: function l1FeeOverhead() view returns(uint256)
+func (_L1block *L1blockSession) L1FeeOverhead() (*big.Int, error) {
+	return _L1block.Contract.L1FeeOverhead(&_L1block.CallOpts)
+}
+
+// L1FeeOverhead is a free data retrieval call binding the contract method 0x8b239f73.
+//
+// Solidity: function l1FeeOverhead() view returns(uint256)
+func (_L1block *L1blockCallerSession) L1FeeOverhead() (*big.Int, error) {
+	return _L1block.Contract.L1FeeOverhead(&_L1block.CallOpts)
+}
+
+// L1FeeScalar is a free data retrieval call binding the contract method 0x9e8c4966.
+//
+// Solidity: function l1FeeScalar() view returns(uint256)
+func (_L1block *L1blockCaller) L1FeeScalar(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _L1block.contract.Call(opts, &out, "l1FeeScalar")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// L1FeeScalar is a free data retrieval call binding the contract method 0x9e8c4966.
+//
+// Solidity: function l1FeeScalar() view returns(uint256)
+func (_L1block *L1blockSession) L1FeeScalar() (*big.Int, error) {
+	return _L1block.Contract.L1FeeScalar(&_L1block.CallOpts)
+}
+
+// L1FeeScalar is a free data retrieval call binding the contract method 0x9e8c4966.
+//
+// Solidity: function l1FeeScalar() view returns(uint256)
+func (_L1block *L1blockCallerSession) L1FeeScalar() (*big.Int, error) {
+	return _L1block.Contract.L1FeeScalar(&_L1block.CallOpts)
+}
+
 // Number is a free data retrieval call binding the contract method 0x8381f58a.
 //
 // Solidity: function number() view returns(uint256)
@@ -325,6 +418,37 @@ func (_L1block *L1blockCallerSession) Number() (*big.Int, error) {
 	return _L1block.Contract.Number(&_L1block.CallOpts)
 }
 
+// SequenceNumber is a free data retrieval call binding the contract method 0x64ca23ef.
+//
+// Solidity: function sequenceNumber() view returns(uint64)
+func (_L1block *L1blockCaller) SequenceNumber(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _L1block.contract.Call(opts, &out, "sequenceNumber")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// SequenceNumber is a free data retrieval call binding the contract method 0x64ca23ef.
+//
+// Solidity: function sequenceNumber() view returns(uint64)
+func (_L1block *L1blockSession) SequenceNumber() (uint64, error) {
+	return _L1block.Contract.SequenceNumber(&_L1block.CallOpts)
+}
+
+// SequenceNumber is a free data retrieval call binding the contract method 0x64ca23ef.
+//
+// Solidity: function sequenceNumber() view returns(uint64)
+func (_L1block *L1blockCallerSession) SequenceNumber() (uint64, error) {
+	return _L1block.Contract.SequenceNumber(&_L1block.CallOpts)
+}
+
 // Timestamp is a free data retrieval call binding the contract method 0xb80777ea.
 //
 // Solidity: function timestamp() view returns(uint256)
@@ -376,3 +500,24 @@ func (_L1block *L1blockSession) SetL1BlockValues(_number *big.Int, _timestamp *b
 func (_L1block *L1blockTransactorSession) SetL1BlockValues(_number *big.Int, _timestamp *big.Int, _basefee *big.Int, _hash [32]byte) (*types.Transaction, error) {
 	return _L1block.Contract.SetL1BlockValues(&_L1block.TransactOpts, _number, _timestamp, _basefee, _hash)
 }
+
+// SetL1BlockValuesV1 is a paid mutator transaction binding the contract method 0x2fea6780.
+//
+// Solidity: function setL1BlockValuesV1(uint64 _number, uint64 _timestamp, uint256 _basefee, bytes32 _hash, uint64 _sequenceNumber, address _batcher, uint256 _l1FeeOverhead, uint256 _l1FeeScalar) returns()
+func (_L1block *L1blockTransactor) SetL1BlockValuesV1(opts *bind.TransactOpts, _number uint64, _timestamp uint64, _basefee *big.Int, _hash [32]byte, _sequenceNumber uint64, _batcher common.Address, _l1FeeOverhead *big.Int, _l1FeeScalar *big.Int) (*types.Transaction, error) {
+	return _L1block.contract.Transact(opts, "setL1BlockValuesV1", _number, _timestamp, _basefee, _hash, _sequenceNumber, _batcher, _l1FeeOverhead, _l1FeeScalar)
+}
+
+// SetL1BlockValuesV1 is a paid mutator transaction binding the contract method 0x2fea6780.
+//
+// Solidity: function setL1BlockValuesV1(uint64 _number, uint64 _timestamp, uint256 _basefee, bytes32 _hash, uint64 _sequenceNumber, address _batcher, uint256 _l1FeeOverhead, uint256 _l1FeeScalar) returns()
+func (_L1block *L1blockSession) SetL1BlockValuesV1(_number uint64, _timestamp uint64, _basefee *big.Int, _hash [32]byte, _sequenceNumber uint64, _batcher common.Address, _l1FeeOverhead *big.Int, _l1FeeScalar *big.Int) (*types.Transaction, error) {
+	return _L1block.Contract.SetL1BlockValuesV1(&_L1block.TransactOpts, _number, _timestamp, _basefee, _hash, _sequenceNumber, _batcher, _l1FeeOverhead, _l1FeeScalar)
+}
+
+// SetL1BlockValuesV1 is a paid mutator transaction binding the contract method 0x2fea6780.
+//
+// Solidity: function setL1BlockValuesV1(uint64 _number, uint64 _timestamp, uint256 _basefee, bytes32 _hash, uint64 _sequenceNumber, address _batcher, uint256 _l1FeeOverhead, uint256 _l1FeeScalar) returns()
+func (_L1block *L1blockTransactorSession) SetL1BlockValuesV1(_number uint64, _timestamp uint64, _basefee *big.Int, _hash [32]byte, _sequenceNumber uint64, _batcher common.Address, _l1FeeOverhead *big.Int, _l1FeeScalar *big.Int) (*types.Transaction, error) {
+	return _L1block.Contract.SetL1BlockValuesV1(&_L1block.TransactOpts, _number, _timestamp, _basefee, _hash, _sequenceNumber, _batcher, _l1FeeOverhead, _l1FeeScalar)
+}
@@ -25,6 +25,8 @@ type L2BlockRef struct {
 	Self     BlockID `json:"self"`
 	Parent   BlockID `json:"parent"`
 	L1Origin BlockID `json:"l1_origin"`
+	// SequenceNumber is the distance to the first L2 block of the epoch, 0 if the block starts the epoch
+	SequenceNumber uint64 `json:"sequence_number"`
 }
 
 func (id L2BlockRef) String() string {
@@ -0,0 +1,15 @@
+package eth
+
+// SyncStatus is a snapshot of the chain heads tracked by the rollup driver.
+type SyncStatus struct {
+	// HeadL1 is the latest known head of the L1 chain
+	HeadL1 BlockID `json:"head_l1"`
+	// CurrentL1 is the L1 parent of the safe L2 head: the L1 chain has been derived up to and including this block
+	CurrentL1 BlockID `json:"current_l1"`
+	// UnsafeL2 is the L2 head, which may include blocks that have not been derived from L1 yet
+	UnsafeL2 BlockID `json:"unsafe_l2"`
+	// SafeL2 is the L2 head as derived from L1
+	SafeL2 BlockID `json:"safe_l2"`
+	// FinalizedL2 is the L2 block that will never be reversed, derived from finalized L1 data
+	FinalizedL2 BlockID `json:"finalized_l2"`
+}
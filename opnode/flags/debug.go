@@ -0,0 +1,17 @@
+package flags
+
+import "github.com/urfave/cli"
+
+// Flags of the debug commands
+
+var (
+	DebugL1RangeFlag = cli.StringFlag{
+		Name:  "l1-range",
+		Usage: "Inclusive range A..B of L1 block numbers of the epochs to debug",
+	}
+)
+
+// DebugBatchesFlags contains the list of options of the debug batches command.
+var DebugBatchesFlags = []cli.Flag{
+	DebugL1RangeFlag,
+}
@@ -1,6 +1,12 @@
 package flags
 
-import "github.com/urfave/cli"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/urfave/cli"
+)
 
 // Flags
 
@@ -13,27 +19,49 @@ func prefixEnvVar(name string) string {
 var (
 	/* Required Flags */
 	L1NodeAddr = cli.StringFlag{
-		Name:     "l1",
-		Usage:    "Address of L1 User JSON-RPC endpoint to use (eth namespace required)",
-		Required: true,
-		Value:    "http://127.0.0.1:8545",
-		EnvVar:   prefixEnvVar("L1_ETH_RPC"),
+		Name:   "l1",
+		Usage:  "Address of L1 User JSON-RPC endpoint to use (eth namespace required)",
+		Value:  "http://127.0.0.1:8545",
+		EnvVar: prefixEnvVar("L1_ETH_RPC"),
 	}
 	L2EngineAddrs = cli.StringSliceFlag{
-		Name:     "l2",
-		Usage:    "Addresses of L2 Engine JSON-RPC endpoints to use (engine and eth namespace required)",
-		Required: true,
-		EnvVar:   prefixEnvVar("L2_ENGINE_RPC"),
-	}
-	RollupConfig = cli.StringFlag{
-		Name:     "rollup.config",
-		Usage:    "Rollup chain parameters",
-		Required: true,
-		EnvVar:   prefixEnvVar("ROLLUP_CONFIG"),
+		Name:   "l2",
+		Usage:  "Addresses of L2 Engine JSON-RPC endpoints to use (engine and eth namespace required)",
+		EnvVar: prefixEnvVar("L2_ENGINE_RPC"),
 	}
 
 	/* Optional Flags */
 
+	RPCListenAddr = cli.StringFlag{
+		Name:   "rpc.addr",
+		Usage:  "RPC listening address",
+		Value:  "127.0.0.1",
+		EnvVar: prefixEnvVar("RPC_ADDR"),
+	}
+	RPCListenPort = cli.IntFlag{
+		Name:   "rpc.port",
+		Usage:  "RPC listening port",
+		Value:  7545,
+		EnvVar: prefixEnvVar("RPC_PORT"),
+	}
+	ConfigFlag = cli.StringFlag{
+		Name:   "config",
+		Usage:  "YAML config file of flag values, overridden by flags and environment variables",
+		EnvVar: prefixEnvVar("CONFIG"),
+	}
+
+	RollupConfig = cli.StringFlag{
+		Name:   "rollup.config",
+		Usage:  "Rollup chain parameters, a JSON file. Either this or --network is required",
+		EnvVar: prefixEnvVar("ROLLUP_CONFIG"),
+	}
+	NetworkFlag = cli.StringFlag{
+		Name: "network",
+		Usage: fmt.Sprintf("Predefined rollup chain parameters, instead of --rollup.config. One of: %s",
+			strings.Join(rollup.NetworkNames(), ", ")),
+		EnvVar: prefixEnvVar("NETWORK"),
+	}
+
 	SequencingEnabledFlag = cli.BoolFlag{
 		Name:   "sequencing.enabled",
 		Usage:  "enable sequencing",
@@ -43,9 +71,40 @@ var (
 	// TODO: move batch submitter to stand-alone process
 	BatchSubmitterKeyFlag = cli.StringFlag{
 		Name:   "batchsubmitter.key",
-		Usage:  "key for batch submitting",
+		Usage:  "Unencrypted hex private key file for batch submitting",
 		EnvVar: prefixEnvVar("BATCHSUBMITTER_KEY"),
 	}
+	BatchSubmitterKeystoreFlag = cli.StringFlag{
+		Name:   "batchsubmitter.keystore",
+		Usage:  "Encrypted JSON keystore file for batch submitting",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_KEYSTORE"),
+	}
+	BatchSubmitterPasswordFlag = cli.StringFlag{
+		Name:   "batchsubmitter.keystore.password",
+		Usage:  "File with the passphrase of the batch submitter keystore",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_KEYSTORE_PASSWORD"),
+	}
+	BatchSubmitterSignerFlag = cli.StringFlag{
+		Name:   "batchsubmitter.signer.endpoint",
+		Usage:  "JSON-RPC endpoint of a remote signer (eth_signTransaction) for batch submitting",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_SIGNER_ENDPOINT"),
+	}
+	BatchSubmitterSignerAddrFlag = cli.StringFlag{
+		Name:   "batchsubmitter.signer.address",
+		Usage:  "Account of the remote signer to submit batches with",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_SIGNER_ADDRESS"),
+	}
+
+	BatchSubmitterBalanceWarnFlag = cli.StringFlag{
+		Name:   "batchsubmitter.balance.warn",
+		Usage:  "Balance of the batch submitter, in ether, below which a warning is logged",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_BALANCE_WARN"),
+	}
+	BatchSubmitterBalanceMinFlag = cli.StringFlag{
+		Name:   "batchsubmitter.balance.min",
+		Usage:  "Balance of the batch submitter, in ether, below which batch submission is paused",
+		EnvVar: prefixEnvVar("BATCHSUBMITTER_BALANCE_MIN"),
+	}
 
 	LogLevelFlag = cli.StringFlag{
 		Name:   "log.level",
@@ -66,19 +125,37 @@ var (
 	}
 )
 
-var requiredFlags = []cli.Flag{
+// RequiredFlags must be set on the command line, in the environment or in the
+// config file. They are checked once the config file is loaded.
+var RequiredFlags = []cli.Flag{
 	L1NodeAddr,
 	L2EngineAddrs,
-	RollupConfig,
 }
 
 var optionalFlags = []cli.Flag{
+	RPCListenAddr,
+	RPCListenPort,
+	ConfigFlag,
+	RollupConfig,
+	NetworkFlag,
 	SequencingEnabledFlag,
 	BatchSubmitterKeyFlag,
+	BatchSubmitterKeystoreFlag,
+	BatchSubmitterPasswordFlag,
+	BatchSubmitterSignerFlag,
+	BatchSubmitterSignerAddrFlag,
+	BatchSubmitterBalanceWarnFlag,
+	BatchSubmitterBalanceMinFlag,
 	LogLevelFlag,
 	LogFormatFlag,
 	LogColorFlag,
 }
 
+// SecretFlags are redacted when the config is dumped: the private key and keystore passphrase of the batch submitter.
+var SecretFlags = []cli.Flag{
+	BatchSubmitterKeyFlag,
+	BatchSubmitterPasswordFlag,
+}
+
 // Flags contains the list of configuration options available to the binary.
-var Flags = append(requiredFlags, optionalFlags...)
+var Flags = append(RequiredFlags, optionalFlags...)
@@ -0,0 +1,93 @@
+package flags
+
+import "github.com/urfave/cli"
+
+// Flags of the genesis command
+
+var (
+	GenesisL1RPCFlag = cli.StringFlag{
+		Name:  "l1",
+		Usage: "L1 JSON-RPC endpoint to read the L1 start block from. Either this or --l1.genesis is required",
+	}
+	GenesisL1BlockFlag = cli.Uint64Flag{
+		Name:  "l1.block",
+		Usage: "Number of the L1 block that the rollup starts after, with --l1. Defaults to the latest block",
+	}
+	GenesisL1FileFlag = cli.StringFlag{
+		Name:  "l1.genesis",
+		Usage: "L1 genesis file of a new L1 chain, that the rollup starts after. The deposit contract is added to it",
+	}
+	GenesisL1OutFlag = cli.StringFlag{
+		Name:  "l1.genesis.out",
+		Usage: "Output file of the L1 genesis with the deposit contract, with --l1.genesis",
+	}
+	GenesisL2FileFlag = cli.StringFlag{
+		Name:  "l2.genesis",
+		Usage: "L2 genesis file with the L2 allocation. The L1Block predeploy is added to it",
+	}
+	GenesisL2OutFlag = cli.StringFlag{
+		Name:  "l2.genesis.out",
+		Usage: "Output file of the L2 genesis with the predeploys, to initialize the L2 execution engine with",
+	}
+	GenesisOutFlag = cli.StringFlag{
+		Name:  "out",
+		Usage: "Output file of the rollup config. Defaults to stdout",
+	}
+
+	GenesisBlockTimeFlag = cli.Uint64Flag{
+		Name:  "block.time",
+		Usage: "Seconds per L2 block",
+		Value: 2,
+	}
+	GenesisMaxSequencerTimeDiffFlag = cli.Uint64Flag{
+		Name:  "sequencer.max.time.diff",
+		Usage: "Seconds that sequencer batches may be after the L1 timestamp of the sequencing window end",
+		Value: 10,
+	}
+	GenesisSeqWindowSizeFlag = cli.Uint64Flag{
+		Name:  "sequencer.window.size",
+		Usage: "Number of L1 blocks per sequencing window",
+		Value: 64,
+	}
+	GenesisFeeRecipientFlag = cli.StringFlag{
+		Name:  "fee.recipient",
+		Usage: "L2 address receiving all L2 transaction fees",
+	}
+	GenesisBatchInboxFlag = cli.StringFlag{
+		Name:  "batch.inbox",
+		Usage: "L1 address that batches are sent to",
+	}
+	GenesisBatchSenderFlag = cli.StringFlag{
+		Name:  "batch.sender",
+		Usage: "L1 address of the batch submitter",
+	}
+	GenesisL1FeeOverheadFlag = cli.Uint64Flag{
+		Name:  "l1fee.overhead",
+		Usage: "L1 gas added to the calldata gas of every L2 transaction",
+		Value: 2100,
+	}
+	GenesisL1FeeScalarFlag = cli.Uint64Flag{
+		Name:  "l1fee.scalar",
+		Usage: "Scalar of the L1 data fee, with 6 decimals",
+		Value: 1_000_000,
+	}
+)
+
+// GenesisFlags contains the list of options of the genesis command.
+var GenesisFlags = []cli.Flag{
+	GenesisL1RPCFlag,
+	GenesisL1BlockFlag,
+	GenesisL1FileFlag,
+	GenesisL1OutFlag,
+	GenesisL2FileFlag,
+	GenesisL2OutFlag,
+	GenesisOutFlag,
+	GenesisBlockTimeFlag,
+	GenesisMaxSequencerTimeDiffFlag,
+	GenesisSeqWindowSizeFlag,
+	GenesisFeeRecipientFlag,
+	GenesisBatchInboxFlag,
+	GenesisBatchSenderFlag,
+	GenesisL1FeeOverheadFlag,
+	GenesisL1FeeScalarFlag,
+}
@@ -0,0 +1,103 @@
+// Package genesis builds a consistent rollup config from the L1 chain and the L2 genesis.
+package genesis
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/deposit"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/l1block"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Params are the parameters of the rollup config that are not derived from the L1 chain or the L2 genesis.
+type Params struct {
+	BlockTime            uint64
+	MaxSequencerTimeDiff uint64
+	SeqWindowSize        uint64
+
+	FeeRecipientAddress common.Address
+	BatchInboxAddress   common.Address
+	BatchSenderAddress  common.Address
+
+	L1FeeOverhead uint64
+	L1FeeScalar   uint64
+}
+
+// AddL1Predeploys adds the deposit contract to the allocation of the L1 genesis.
+func AddL1Predeploys(g *core.Genesis) error {
+	return addPredeploy(g, derive.DepositContractAddr, common.FromHex(deposit.DepositDeployedBin))
+}
+
+// AddL2Predeploys adds the L1Block contract, which receives the L1 info deposit of every L2 block, to the allocation
+// of the L2 genesis.
+func AddL2Predeploys(g *core.Genesis) error {
+	return addPredeploy(g, derive.L1InfoPredeployAddr, common.FromHex(l1block.L1blockDeployedBin))
+}
+
+func addPredeploy(g *core.Genesis, addr common.Address, code []byte) error {
+	if g.Alloc == nil {
+		g.Alloc = make(core.GenesisAlloc)
+	}
+	account := g.Alloc[addr]
+	if len(account.Code) > 0 && !bytes.Equal(account.Code, code) {
+		return fmt.Errorf("genesis allocates different code to predeploy %s", addr)
+	}
+	account.Code = code
+	if account.Balance == nil {
+		account.Balance = common.Big0
+	}
+	g.Alloc[addr] = account
+	return nil
+}
+
+// RollupConfig builds the rollup config of a rollup that starts after the given L1 block, from the L2 genesis.
+// If the L2 genesis has no timestamp, it is set to the time of the L1 block, such that the L2 genesis hash in the
+// rollup config matches the updated L2 genesis. All forks are active from the L2 genesis.
+//
+// The L2 genesis must already contain the L2 predeploys, see AddL2Predeploys.
+func RollupConfig(l1Start *types.Header, l1ChainID *big.Int, l2 *core.Genesis, params Params) (*rollup.Config, error) {
+	if l2.Config == nil || l2.Config.ChainID == nil {
+		return nil, errors.New("l2 genesis has no chain id")
+	}
+	if l2.Config.TerminalTotalDifficulty == nil || l2.Config.TerminalTotalDifficulty.Sign() != 0 {
+		return nil, errors.New("l2 genesis must activate the merge from genesis, with a terminal total difficulty of 0")
+	}
+	if l2.Timestamp == 0 {
+		l2.Timestamp = l1Start.Time
+	}
+	l2Block := l2.ToBlock(nil)
+
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     eth.BlockID{Hash: l1Start.Hash(), Number: l1Start.Number.Uint64()},
+			L2:     eth.BlockID{Hash: l2Block.Hash(), Number: l2Block.NumberU64()},
+			L2Time: l2Block.Time(),
+		},
+		BlockTime:            params.BlockTime,
+		MaxSequencerTimeDiff: params.MaxSequencerTimeDiff,
+		SeqWindowSize:        params.SeqWindowSize,
+		L1ChainID:            new(big.Int).Set(l1ChainID),
+		L2ChainID:            new(big.Int).Set(l2.Config.ChainID),
+		FeeRecipientAddress:  params.FeeRecipientAddress,
+		BatchInboxAddress:    params.BatchInboxAddress,
+		BatchSenderAddress:   params.BatchSenderAddress,
+		L1FeeOverhead:        params.L1FeeOverhead,
+		L1FeeScalar:          params.L1FeeScalar,
+		Forks:                make(rollup.ForkTimes, len(rollup.Forks)),
+	}
+	for _, fork := range rollup.Forks {
+		cfg.Forks[fork] = l2Block.Time()
+	}
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid rollup config: %w", err)
+	}
+	return cfg, nil
+}
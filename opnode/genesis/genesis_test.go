@@ -0,0 +1,96 @@
+package genesis
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func readGenesis(t *testing.T, path string) *core.Genesis {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var g core.Genesis
+	require.NoError(t, json.Unmarshal(data, &g))
+	return &g
+}
+
+func devnetParams() Params {
+	devnet := rollup.Networks["devnet"]
+	return Params{
+		BlockTime:            devnet.BlockTime,
+		MaxSequencerTimeDiff: devnet.MaxSequencerTimeDiff,
+		SeqWindowSize:        devnet.SeqWindowSize,
+		FeeRecipientAddress:  devnet.FeeRecipientAddress,
+		BatchInboxAddress:    devnet.BatchInboxAddress,
+		BatchSenderAddress:   devnet.BatchSenderAddress,
+		L1FeeOverhead:        devnet.L1FeeOverhead,
+		L1FeeScalar:          devnet.L1FeeScalar,
+	}
+}
+
+// TestDevnetRollupConfig asserts that the devnet preset is the rollup config of the devnet genesis files under ops/.
+func TestDevnetRollupConfig(t *testing.T) {
+	l1 := readGenesis(t, "../../ops/genesis-l1.json")
+	require.NoError(t, AddL1Predeploys(l1))
+	l2 := readGenesis(t, "../../ops/genesis-l2.json")
+	require.NoError(t, AddL2Predeploys(l2))
+
+	cfg, err := RollupConfig(l1.ToBlock(nil).Header(), l1.Config.ChainID, l2, devnetParams())
+	require.NoError(t, err)
+	require.Equal(t, rollup.Networks["devnet"], cfg)
+}
+
+func TestPredeploys(t *testing.T) {
+	g := &core.Genesis{}
+	require.NoError(t, AddL2Predeploys(g))
+	require.NotEmpty(t, g.Alloc[derive.L1InfoPredeployAddr].Code)
+	// Adding the predeploys again is a no-op.
+	require.NoError(t, AddL2Predeploys(g))
+
+	g.Alloc[derive.DepositContractAddr] = core.GenesisAccount{Code: []byte{0x01}, Balance: common.Big0}
+	require.Error(t, AddL1Predeploys(g))
+}
+
+func TestRollupConfig(t *testing.T) {
+	l1Start := &types.Header{Number: big.NewInt(100), Time: 1000, Difficulty: common.Big1}
+	l2 := &core.Genesis{
+		Config: &params.ChainConfig{
+			ChainID:                 big.NewInt(901),
+			TerminalTotalDifficulty: common.Big0,
+		},
+		GasLimit:   5_000_000,
+		Difficulty: common.Big1,
+	}
+	require.NoError(t, AddL2Predeploys(l2))
+
+	cfg, err := RollupConfig(l1Start, big.NewInt(900), l2, devnetParams())
+	require.NoError(t, err)
+	// The L2 genesis starts at the time of the L1 start block.
+	require.Equal(t, uint64(1000), l2.Timestamp)
+	require.Equal(t, uint64(1000), cfg.Genesis.L2Time)
+	require.Equal(t, l2.ToBlock(nil).Hash(), cfg.Genesis.L2.Hash)
+	require.Equal(t, l1Start.Hash(), cfg.Genesis.L1.Hash)
+	require.Equal(t, uint64(100), cfg.Genesis.L1.Number)
+	require.Equal(t, big.NewInt(901), cfg.L2ChainID)
+
+	// The L2 genesis must be merged from genesis.
+	l2.Config.TerminalTotalDifficulty = nil
+	_, err = RollupConfig(l1Start, big.NewInt(900), l2, devnetParams())
+	require.Error(t, err)
+
+	// The rollup config must be valid.
+	l2.Config.TerminalTotalDifficulty = common.Big0
+	params := devnetParams()
+	params.BatchSenderAddress = common.Address{}
+	_, err = RollupConfig(l1Start, big.NewInt(900), l2, params)
+	require.Error(t, err)
+}
@@ -0,0 +1,70 @@
+// Package testsigner provides a remote signer stub for tests.
+package testsigner
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"net/http/httptest"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Server serves eth_signTransaction over HTTP, signing with a single local key.
+type Server struct {
+	Addr common.Address
+
+	rpcServer  *rpc.Server
+	httpServer *httptest.Server
+}
+
+// NewServer starts a signer server for the key
+func NewServer(key *ecdsa.PrivateKey) (*Server, error) {
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	rpcServer := rpc.NewServer()
+	if err := rpcServer.RegisterName("eth", &ethAPI{key: key, addr: addr}); err != nil {
+		return nil, err
+	}
+	return &Server{
+		Addr:       addr,
+		rpcServer:  rpcServer,
+		httpServer: httptest.NewServer(rpcServer),
+	}, nil
+}
+
+// Endpoint is the HTTP endpoint of the signer
+func (s *Server) Endpoint() string {
+	return s.httpServer.URL
+}
+
+func (s *Server) Close() {
+	s.httpServer.Close()
+	s.rpcServer.Stop()
+}
+
+type ethAPI struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+func (api *ethAPI) SignTransaction(args signer.TransactionArgs) (*signer.SignTransactionResult, error) {
+	if args.From == nil || *args.From != api.addr {
+		return nil, fmt.Errorf("unknown account %v", args.From)
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), api.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &signer.SignTransactionResult{Raw: raw, Tx: signed}, nil
+}
@@ -79,14 +79,14 @@ func (s Source) FetchReceipts(ctx context.Context, id eth.BlockID) ([]*types.Rec
 	return receipts, err
 }
 
-func (s Source) FetchTransactions(ctx context.Context, window []eth.BlockID) ([]*types.Transaction, error) {
-	var txns []*types.Transaction
+func (s Source) FetchTransactions(ctx context.Context, window []eth.BlockID) ([][]*types.Transaction, error) {
+	txns := make([][]*types.Transaction, 0, len(window))
 	for _, id := range window {
 		block, err := s.client.BlockByHash(ctx, id.Hash)
 		if err != nil {
 			return nil, err
 		}
-		txns = append(txns, block.Transactions()...)
+		txns = append(txns, block.Transactions())
 	}
 	return txns, nil
 
@@ -0,0 +1,104 @@
+package l2
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// OutputRootVersionV0 is the version of the L2 output root construction:
+// keccak256(version ++ state_root ++ withdrawal_storage_root ++ latest_block_hash)
+var OutputRootVersionV0 = Bytes32{}
+
+// WithdrawalContractAddr is the L2 predeploy that withdrawals are stored in.
+// Until the withdrawals contract is deployed the withdrawal storage root is the empty storage root.
+var WithdrawalContractAddr = common.HexToAddress("0x4200000000000000000000000000000000000016")
+
+// ComputeL2OutputRoot computes the L2 output root of the given version, see specs/proposals.md
+func ComputeL2OutputRoot(version Bytes32, stateRoot common.Hash, withdrawalStorageRoot common.Hash, blockHash common.Hash) Bytes32 {
+	var buf bytes.Buffer
+	buf.Write(version[:])
+	buf.Write(stateRoot[:])
+	buf.Write(withdrawalStorageRoot[:])
+	buf.Write(blockHash[:])
+	return Bytes32(crypto.Keccak256Hash(buf.Bytes()))
+}
+
+// AccountResult is the result of eth_getProof, without storage proofs
+type AccountResult struct {
+	Address      common.Address  `json:"address"`
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	Balance      *hexutil.Big    `json:"balance"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	StorageHash  common.Hash     `json:"storageHash"`
+}
+
+// Verify checks the account proof against the given state root, and returns an error if the account data does not match.
+func (res *AccountResult) Verify(stateRoot common.Hash) error {
+	db := memorydb.New()
+	for i, node := range res.AccountProof {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return fmt.Errorf("failed to load proof node %d: %v", i, err)
+		}
+	}
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(res.Address[:]), db)
+	if err != nil {
+		return fmt.Errorf("failed to verify account proof of %s: %v", res.Address, err)
+	}
+	if len(value) == 0 {
+		// account does not exist: the storage root must be empty
+		if res.StorageHash != types.EmptyRootHash {
+			return fmt.Errorf("proof shows non-existent account %s, but storage hash is %s", res.Address, res.StorageHash)
+		}
+		return nil
+	}
+	var acc types.StateAccount
+	if err := rlp.DecodeBytes(value, &acc); err != nil {
+		return fmt.Errorf("failed to decode account %s from proof: %v", res.Address, err)
+	}
+	if acc.Root != res.StorageHash {
+		return fmt.Errorf("account %s storage hash mismatch: proof has %s, result has %s", res.Address, acc.Root, res.StorageHash)
+	}
+	return nil
+}
+
+// GetProof returns the proof of the account at the given block, verified against the state root of the block.
+func (s *Source) GetProof(ctx context.Context, address common.Address, header *types.Header) (*AccountResult, error) {
+	var result AccountResult
+	err := s.rpc.CallContext(ctx, &result, "eth_getProof", address, []common.Hash{}, hexutil.EncodeBig(header.Number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch proof of %s at block %d: %v", address, header.Number, err)
+	}
+	if result.Address != address {
+		return nil, fmt.Errorf("proof is for account %s, but requested %s", result.Address, address)
+	}
+	if err := result.Verify(header.Root); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// OutputAtBlock fetches the L2 block header and withdrawal storage root at the given block number,
+// and returns the version and L2 output root of the block.
+func (s *Source) OutputAtBlock(ctx context.Context, number *big.Int) (version Bytes32, root Bytes32, err error) {
+	header, err := s.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return Bytes32{}, Bytes32{}, fmt.Errorf("failed to fetch L2 block header %d: %v", number, err)
+	}
+	proof, err := s.GetProof(ctx, WithdrawalContractAddr, header)
+	if err != nil {
+		return Bytes32{}, Bytes32{}, err
+	}
+	root = ComputeL2OutputRoot(OutputRootVersionV0, header.Root, proof.StorageHash, header.Hash())
+	return OutputRootVersionV0, root, nil
+}
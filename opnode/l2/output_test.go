@@ -0,0 +1,69 @@
+package l2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeL2OutputRoot(t *testing.T) {
+	stateRoot := common.Hash{0x01}
+	storageRoot := common.Hash{0x02}
+	blockHash := common.Hash{0x03}
+	var preimage []byte
+	preimage = append(preimage, make([]byte, 32)...) // version 0
+	preimage = append(preimage, stateRoot[:]...)
+	preimage = append(preimage, storageRoot[:]...)
+	preimage = append(preimage, blockHash[:]...)
+	require.Equal(t, Bytes32(crypto.Keccak256Hash(preimage)), ComputeL2OutputRoot(OutputRootVersionV0, stateRoot, storageRoot, blockHash))
+	require.NotEqual(t, ComputeL2OutputRoot(Bytes32{1}, stateRoot, storageRoot, blockHash), ComputeL2OutputRoot(OutputRootVersionV0, stateRoot, storageRoot, blockHash))
+}
+
+func TestAccountResultVerify(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	withStorage := common.Address{0xaa}
+	statedb.SetBalance(withStorage, big.NewInt(1000))
+	statedb.SetState(withStorage, common.Hash{0x01}, common.Hash{0x02})
+	statedb.SetNonce(common.Address{0xbb}, 1)
+	stateRoot := statedb.IntermediateRoot(false)
+
+	proofOf := func(addr common.Address) *AccountResult {
+		proof, err := statedb.GetProof(addr)
+		require.NoError(t, err)
+		res := &AccountResult{Address: addr, StorageHash: types.EmptyRootHash}
+		for _, node := range proof {
+			res.AccountProof = append(res.AccountProof, hexutil.Bytes(node))
+		}
+		if trie := statedb.StorageTrie(addr); trie != nil {
+			res.StorageHash = trie.Hash()
+		}
+		return res
+	}
+
+	res := proofOf(withStorage)
+	require.NotEqual(t, types.EmptyRootHash, res.StorageHash)
+	require.NoError(t, res.Verify(stateRoot))
+
+	t.Run("wrong storage hash", func(t *testing.T) {
+		res := proofOf(withStorage)
+		res.StorageHash = types.EmptyRootHash
+		require.Error(t, res.Verify(stateRoot))
+	})
+	t.Run("wrong state root", func(t *testing.T) {
+		require.Error(t, proofOf(withStorage).Verify(common.Hash{0x42}))
+	})
+	t.Run("non-existent account", func(t *testing.T) {
+		res := proofOf(common.Address{0xcc})
+		require.NoError(t, res.Verify(stateRoot))
+		res.StorageHash = common.Hash{0x01}
+		require.Error(t, res.Verify(stateRoot))
+	})
+}
@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type l2EthClient interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	// OutputAtBlock returns the version and L2 output root of the given block
+	OutputAtBlock(ctx context.Context, number *big.Int) (version l2.Bytes32, root l2.Bytes32, err error)
+}
+
+type driverClient interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// nodeAPI is served in the "optimism" namespace of the rollup node RPC
+type nodeAPI struct {
+	config *rollup.Config
+	client l2EthClient
+	dr     driverClient
+	log    log.Logger
+}
+
+func newNodeAPI(config *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger) *nodeAPI {
+	return &nodeAPI{
+		config: config,
+		client: l2Client,
+		dr:     dr,
+		log:    log,
+	}
+}
+
+// SyncStatus returns the L1 and L2 heads of the rollup node: outputs of L2 blocks up to the safe head
+// have been derived from L1, and are the outputs that may be proposed.
+func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return n.dr.SyncStatus(ctx)
+}
+
+// OutputAtBlock returns the version and L2 output root of the given L2 block, see specs/proposals.md
+func (n *nodeAPI) OutputAtBlock(ctx context.Context, number hexutil.Uint64) ([]l2.Bytes32, error) {
+	version, root, err := n.client.OutputAtBlock(ctx, new(big.Int).SetUint64(uint64(number)))
+	if err != nil {
+		n.log.Warn("failed to compute L2 output", "block", uint64(number), "err", err)
+		return nil, err
+	}
+	return []l2.Bytes32{version, root}, nil
+}
+
+// EstimateL1Fee returns the L1 data fee that the given opaque L2 transaction would be charged,
+// based on the L1 info of the latest L2 block.
+func (n *nodeAPI) EstimateL1Fee(ctx context.Context, tx hexutil.Bytes) (*hexutil.Big, error) {
+	var parsed types.Transaction
+	if err := parsed.UnmarshalBinary(tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %v", err)
+	}
+	head, err := n.client.BlockByNumber(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest L2 block: %v", err)
+	}
+	txs := head.Transactions()
+	if len(txs) == 0 || txs[0].Type() != types.DepositTxType {
+		return nil, errors.New("latest L2 block is missing L1 info deposit tx")
+	}
+	info, err := derive.L1InfoDepositTxData(txs[0].Data())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse L1 info of latest L2 block %s: %v", head.Hash(), err)
+	}
+	return (*hexutil.Big)(derive.L1Cost(tx, &info)), nil
+}
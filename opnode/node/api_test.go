@@ -0,0 +1,91 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/internal/testlog"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeL2Client struct {
+	head *types.Block
+}
+
+func (f *fakeL2Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	if number != nil {
+		return nil, errors.New("only the latest block is available")
+	}
+	return f.head, nil
+}
+
+func (f *fakeL2Client) OutputAtBlock(ctx context.Context, number *big.Int) (l2.Bytes32, l2.Bytes32, error) {
+	return l2.Bytes32{}, l2.Bytes32{}, errors.New("not implemented")
+}
+
+func TestEstimateL1Fee(t *testing.T) {
+	cfg := &rollup.Config{
+		BatchSenderAddress: common.Address{0xba},
+		L1FeeOverhead:      2100,
+		L1FeeScalar:        1_500_000,
+		Forks:              rollup.ForkTimes{rollup.L1InfoV1Fork: 0},
+	}
+	l1Block := types.NewBlockWithHeader(&types.Header{
+		Number:  big.NewInt(100),
+		Time:    1000,
+		BaseFee: big.NewInt(7),
+	})
+	l2Head := func(l2Time uint64) *types.Block {
+		dep, err := derive.L1InfoDeposit(0, l1Block, l2Time, cfg)
+		require.NoError(t, err)
+		return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1), Time: l2Time}).
+			WithBody([]*types.Transaction{types.NewTx(dep)}, nil)
+	}
+
+	tx := types.NewTransaction(3, common.Address{0x42}, big.NewInt(1), 21000, big.NewInt(1), []byte{0x00, 0xff})
+	opaque, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	var dataGas uint64
+	for _, b := range opaque {
+		if b == 0 {
+			dataGas += 4
+		} else {
+			dataGas += 16
+		}
+	}
+
+	client := &fakeL2Client{head: l2Head(1002)}
+	api := newNodeAPI(cfg, client, nil, testlog.Logger(t, log.LvlError))
+
+	fee, err := api.EstimateL1Fee(context.Background(), opaque)
+	require.NoError(t, err)
+	// (data gas + overhead) * L1 basefee * 1.5
+	expected := new(big.Int).SetUint64((dataGas + 2100) * 7 * 3 / 2)
+	require.Equal(t, expected, fee.ToInt())
+
+	t.Run("legacy L1 info", func(t *testing.T) {
+		cfg.Forks = nil
+		defer func() { cfg.Forks = rollup.ForkTimes{rollup.L1InfoV1Fork: 0} }()
+		client.head = l2Head(1002)
+		fee, err := api.EstimateL1Fee(context.Background(), opaque)
+		require.NoError(t, err)
+		require.Zero(t, fee.ToInt().Sign())
+	})
+	t.Run("missing L1 info", func(t *testing.T) {
+		client.head = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+		_, err := api.EstimateL1Fee(context.Background(), opaque)
+		require.Error(t, err)
+	})
+	t.Run("invalid tx", func(t *testing.T) {
+		_, err := api.EstimateL1Fee(context.Background(), []byte{0x02, 0xc0})
+		require.Error(t, err)
+	})
+}
@@ -1,10 +1,11 @@
 package node
 
 import (
-	"crypto/ecdsa"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 )
 
 type Config struct {
@@ -14,11 +15,22 @@ type Config struct {
 
 	Rollup rollup.Config
 
+	// RPC server of the rollup node
+	RPC RPCConfig
+
 	// Sequencer flag, enables sequencing
 	Sequencer bool
 
-	// SubmitterPrivKey, temporary config var while the batch-submitter is part of the rollup node
-	SubmitterPrivKey *ecdsa.PrivateKey
+	// SubmitterSigner, temporary config var while the batch-submitter is part of the rollup node
+	SubmitterSigner signer.Config
+
+	// SubmitterBalance holds the balance thresholds of the batch submitter
+	SubmitterBalance balance.Config
+}
+
+type RPCConfig struct {
+	ListenAddr string
+	ListenPort int
 }
 
 // Check verifies that the given configuration makes sense
@@ -26,6 +38,17 @@ func (cfg *Config) Check() error {
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %v", err)
 	}
+	if cfg.RPC.ListenPort < 0 || cfg.RPC.ListenPort > 65535 {
+		return fmt.Errorf("invalid RPC listen port: %d", cfg.RPC.ListenPort)
+	}
+	if cfg.Sequencer {
+		if err := cfg.SubmitterSigner.Check(); err != nil {
+			return fmt.Errorf("batch submitter signer config error: %v", err)
+		}
+		if err := cfg.SubmitterBalance.Check(); err != nil {
+			return fmt.Errorf("batch submitter balance config error: %v", err)
+		}
+	}
 
 	return nil
 }
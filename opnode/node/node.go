@@ -7,16 +7,19 @@ import (
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/backoff"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/bss"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/l1"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/driver"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -24,6 +27,7 @@ type OpNode struct {
 	log       log.Logger
 	l1Source  l1.Source        // Source to fetch data from (also implements the Downloader interface)
 	l2Engines []*driver.Driver // engines to keep synced
+	server    *rpcServer
 	done      chan struct{}
 }
 
@@ -59,8 +63,36 @@ func New(ctx context.Context, cfg *Config, log log.Logger) (*OpNode, error) {
 
 	// TODO: we may need to authenticate the connection with L1
 	// l1Node.SetHeader()
+	if err := cfg.Rollup.CheckL1(ctx, ethclient.NewClient(l1Node)); err != nil {
+		return nil, fmt.Errorf("L1 node does not match the rollup config: %w", err)
+	}
 	l1Source := l1.NewSource(ethclient.NewClient(l1Node))
 	var l2Engines []*driver.Driver
+	var server *rpcServer
+
+	var submitterSigner signer.Signer
+	// Wallets whose balance is served by the health endpoint, by name
+	wallets := make(map[string]*balance.Monitor)
+	// Metrics served by the RPC server
+	registry := metrics.NewRegistry()
+	var submitterBalance *balance.Monitor
+	if cfg.Sequencer {
+		submitterSigner, err = signer.NewSigner(ctx, &cfg.SubmitterSigner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create batch submitter signer: %w", err)
+		}
+		submitterBalance = balance.NewMonitor(submitterSigner.Address(), cfg.SubmitterBalance,
+			ethclient.NewClient(l1Node), log.New("wallet", "batch_submitter"))
+		submitterBalance.RegisterMetrics(metrics.NewPrefixedChildRegistry(registry, "opnode/batch_submitter/"))
+		wallets["batch_submitter"] = submitterBalance
+
+		if head, err := ethclient.NewClient(l1Node).BlockNumber(ctx); err != nil {
+			log.Warn("Failed to fetch L1 head to check the batch submitter", "err", err)
+		} else if addr := submitterSigner.Address(); !cfg.Rollup.IsBatchSender(head, addr) {
+			log.Warn("Batch submitter is not an authorized batch sender, its batches will be ignored",
+				"address", addr, "l1_head", head, "authorized", cfg.Rollup.BatchSenders(head))
+		}
+	}
 
 	for i, addr := range cfg.L2EngineAddrs {
 		l2Node, err := dialRPCClientWithBackoff(ctx, log, addr)
@@ -69,6 +101,9 @@ func New(ctx context.Context, cfg *Config, log log.Logger) (*OpNode, error) {
 		}
 		// TODO: we may need to authenticate the connection with L2
 		// backend.SetHeader()
+		if err := cfg.Rollup.CheckL2(ctx, ethclient.NewClient(l2Node)); err != nil {
+			return nil, fmt.Errorf("L2 engine (%s) does not match the rollup config: %w", addr, err)
+		}
 		client, err := l2.NewSource(l2Node, log.New("engine_client", i))
 		if err != nil {
 			return nil, err
@@ -80,17 +115,23 @@ func New(ctx context.Context, cfg *Config, log log.Logger) (*OpNode, error) {
 				Client:    ethclient.NewClient(l1Node),
 				ToAddress: cfg.Rollup.BatchInboxAddress,
 				ChainID:   cfg.Rollup.L1ChainID,
-				PrivKey:   cfg.SubmitterPrivKey,
+				Signer:    submitterSigner,
+				Balance:   submitterBalance,
 			}
 		}
 		engine := driver.NewDriver(cfg.Rollup, client, &l1Source, log.New("engine", i, "Sequencer", cfg.Sequencer), submitter, cfg.Sequencer)
 		l2Engines = append(l2Engines, engine)
+		// The RPC server is backed by the first engine
+		if server == nil {
+			server = newRPCServer(&cfg.RPC, &cfg.Rollup, client, engine, wallets, registry, log.New("rpc"))
+		}
 	}
 
 	n := &OpNode{
 		log:       log,
 		l1Source:  l1Source,
 		l2Engines: l2Engines,
+		server:    server,
 		done:      make(chan struct{}),
 	}
 
@@ -149,6 +190,12 @@ func (c *OpNode) Start(ctx context.Context) error {
 	l1Heads := make(chan eth.L1BlockRef, 10)
 	l1HeadsFeed.Subscribe(l1Heads)
 
+	if c.server != nil {
+		if err := c.server.Start(); err != nil {
+			return fmt.Errorf("unable to start RPC server: %w", err)
+		}
+	}
+
 	c.log.Info("Start-up complete!")
 	go func() {
 
@@ -169,6 +216,10 @@ func (c *OpNode) Start(ctx context.Context) error {
 				for _, eng := range c.l2Engines {
 					eng.Close()
 				}
+				// close the RPC server
+				if c.server != nil {
+					c.server.Stop()
+				}
 				return
 			}
 		}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type rpcServer struct {
+	endpoint   string
+	api        *nodeAPI
+	wallets    map[string]*balance.Monitor
+	registry   metrics.Registry
+	httpServer *http.Server
+	listenAddr net.Addr
+	log        log.Logger
+}
+
+func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient,
+	wallets map[string]*balance.Monitor, registry metrics.Registry, log log.Logger) *rpcServer {
+	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
+	return &rpcServer{
+		endpoint: endpoint,
+		api:      newNodeAPI(rollupCfg, l2Client, dr, log),
+		wallets:  wallets,
+		registry: registry,
+		log:      log,
+	}
+}
+
+func (s *rpcServer) Start() error {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("optimism", s.api); err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", srv)
+	// The health of the node is the balance of the wallets it submits transactions from
+	mux.Handle("/health", balance.HealthHandler(s.wallets))
+	// The metrics of the node, in the Prometheus format
+	mux.Handle("/metrics", prometheus.Handler(s.registry))
+	listener, err := net.Listen("tcp", s.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %v", s.endpoint, err)
+	}
+	s.listenAddr = listener.Addr()
+	s.httpServer = &http.Server{Handler: mux}
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			s.log.Error("http server failed", "err", err)
+		}
+	}()
+	s.log.Info("Started RPC server", "endpoint", s.listenAddr)
+	return nil
+}
+
+func (s *rpcServer) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	_ = s.httpServer.Shutdown(ctx)
+}
+
+// Addr returns the address the server is listening on, nil if the server is not started
+func (s *rpcServer) Addr() net.Addr {
+	return s.listenAddr
+}
@@ -0,0 +1,68 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// BatcherUpdate replaces the set of authorized batch senders, starting at the given L1 block.
+// The first sender is the canonical batcher: the L1 info deposits of the L1 blocks that the update applies to carry
+// its address as the batcher for fee accounting, regardless of which sender submitted the batches.
+type BatcherUpdate struct {
+	// First L1 block number that the senders are authorized in
+	L1Block uint64 `json:"l1_block"`
+	// Authorized batch-sender addresses, starting with the canonical batcher
+	Senders []common.Address `json:"senders"`
+}
+
+// BatchSenders returns the batch senders that are authorized in the L1 block with the given number.
+// This is the BatchSenderAddress, until the first update of the batcher schedule.
+// The first sender is the canonical batcher of the L1 block.
+func (cfg *Config) BatchSenders(l1Num uint64) []common.Address {
+	senders := []common.Address{cfg.BatchSenderAddress}
+	for _, update := range cfg.BatcherSchedule {
+		if update.L1Block > l1Num {
+			break
+		}
+		senders = update.Senders
+	}
+	return senders
+}
+
+// IsBatchSender returns true if the address is authorized to submit batches in the L1 block with the given number.
+func (cfg *Config) IsBatchSender(l1Num uint64, addr common.Address) bool {
+	for _, sender := range cfg.BatchSenders(l1Num) {
+		if sender == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBatcherSchedule verifies that the updates are ordered by L1 block, after the genesis, and authorize at least
+// one sender each.
+func (cfg *Config) checkBatcherSchedule() error {
+	prev := cfg.Genesis.L1.Number
+	for i, update := range cfg.BatcherSchedule {
+		if update.L1Block <= prev {
+			return fmt.Errorf("batcher update %d at L1 block %d must be after L1 block %d", i, update.L1Block, prev)
+		}
+		prev = update.L1Block
+		if len(update.Senders) == 0 {
+			return fmt.Errorf("batcher update %d at L1 block %d has no senders", i, update.L1Block)
+		}
+		seen := make(map[common.Address]struct{}, len(update.Senders))
+		for _, sender := range update.Senders {
+			if sender == (common.Address{}) {
+				return errors.New("batch sender address cannot be empty")
+			}
+			if _, ok := seen[sender]; ok {
+				return fmt.Errorf("batcher update %d at L1 block %d has duplicate sender %s", i, update.L1Block, sender)
+			}
+			seen[sender] = struct{}{}
+		}
+	}
+	return nil
+}
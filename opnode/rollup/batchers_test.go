@@ -0,0 +1,60 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSenders(t *testing.T) {
+	cfg := randConfig()
+	genesisSender := cfg.BatchSenderAddress
+	a, b, c := common.Address{0xa}, common.Address{0xb}, common.Address{0xc}
+	start := cfg.Genesis.L1.Number
+	cfg.BatcherSchedule = []BatcherUpdate{
+		{L1Block: start + 10, Senders: []common.Address{a, b}},
+		{L1Block: start + 20, Senders: []common.Address{c}},
+	}
+	assert.NoError(t, cfg.Check())
+
+	assert.Equal(t, []common.Address{genesisSender}, cfg.BatchSenders(start))
+	assert.Equal(t, []common.Address{genesisSender}, cfg.BatchSenders(start+9))
+	assert.Equal(t, []common.Address{a, b}, cfg.BatchSenders(start+10))
+	assert.Equal(t, []common.Address{a, b}, cfg.BatchSenders(start+19))
+	assert.Equal(t, []common.Address{c}, cfg.BatchSenders(start+20))
+
+	assert.True(t, cfg.IsBatchSender(start+9, genesisSender))
+	assert.False(t, cfg.IsBatchSender(start+10, genesisSender))
+	assert.True(t, cfg.IsBatchSender(start+15, b))
+	assert.False(t, cfg.IsBatchSender(start+20, b))
+	assert.True(t, cfg.IsBatchSender(start+1000, c))
+}
+
+func TestCheckBatcherSchedule(t *testing.T) {
+	a, b := common.Address{0xa}, common.Address{0xb}
+	for name, modify := range map[string]func(cfg *Config){
+		"at genesis": func(cfg *Config) {
+			cfg.BatcherSchedule = []BatcherUpdate{{L1Block: cfg.Genesis.L1.Number, Senders: []common.Address{a}}}
+		},
+		"out of order": func(cfg *Config) {
+			cfg.BatcherSchedule = []BatcherUpdate{
+				{L1Block: cfg.Genesis.L1.Number + 20, Senders: []common.Address{a}},
+				{L1Block: cfg.Genesis.L1.Number + 10, Senders: []common.Address{b}},
+			}
+		},
+		"no senders": func(cfg *Config) {
+			cfg.BatcherSchedule = []BatcherUpdate{{L1Block: cfg.Genesis.L1.Number + 1}}
+		},
+		"empty sender": func(cfg *Config) {
+			cfg.BatcherSchedule = []BatcherUpdate{{L1Block: cfg.Genesis.L1.Number + 1, Senders: []common.Address{{}}}}
+		},
+		"duplicate sender": func(cfg *Config) {
+			cfg.BatcherSchedule = []BatcherUpdate{{L1Block: cfg.Genesis.L1.Number + 1, Senders: []common.Address{a, a}}}
+		},
+	} {
+		cfg := randConfig()
+		modify(cfg)
+		assert.Error(t, cfg.Check(), name)
+	}
+}
@@ -0,0 +1,98 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// EpochAttributes are the payload attributes of the L2 blocks derived from a single epoch, in order.
+type EpochAttributes struct {
+	Epoch      eth.BlockID             `json:"epoch"`
+	Attributes []*l2.PayloadAttributes `json:"attributes"`
+}
+
+// AttributesBuilder is the last stage of the derivation: it turns the batches of an epoch into L2 payload attributes.
+type AttributesBuilder interface {
+	// NextAttributes returns the attributes of the L2 blocks of the next epoch, to be built on top of the L2 parent.
+	NextAttributes(ctx context.Context, parent L2Parent) (*EpochAttributes, error)
+}
+
+// BatchAttributesBuilder prefixes the batched transactions with the L1 info deposit of every L2 block,
+// and includes the user deposits of the epoch in the first L2 block of the epoch.
+type BatchAttributesBuilder struct {
+	config *rollup.Config
+	queue  BatchQueue
+}
+
+var _ AttributesBuilder = (*BatchAttributesBuilder)(nil)
+
+func NewBatchAttributesBuilder(config *rollup.Config, queue BatchQueue) *BatchAttributesBuilder {
+	return &BatchAttributesBuilder{config: config, queue: queue}
+}
+
+func (ab *BatchAttributesBuilder) NextAttributes(ctx context.Context, parent L2Parent) (*EpochAttributes, error) {
+	epochBatches, err := ab.queue.NextBatches(ctx, parent)
+	if err != nil {
+		return nil, err
+	}
+	window := epochBatches.Window
+	epoch := window.EpochID()
+	deposits, err := DeriveDeposits(epoch.Number, window.Receipts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive deposits of epoch %s: %w", epoch, err)
+	}
+	out := &EpochAttributes{Epoch: epoch}
+	for i, batch := range epochBatches.Batches {
+		parent = parent.Next(epoch, batch.Timestamp)
+		l1InfoTx, err := L1InfoDepositBytes(parent.SequenceNumber, window.Epoch, batch.Timestamp, ab.config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create l1InfoTx: %w", err)
+		}
+		var txns []l2.Data
+		txns = append(txns, l1InfoTx)
+		if i == 0 {
+			txns = append(txns, deposits...)
+		}
+		txns = append(txns, batch.Transactions...)
+		out.Attributes = append(out.Attributes, &l2.PayloadAttributes{
+			Timestamp:             hexutil.Uint64(batch.Timestamp),
+			Random:                l2.Bytes32(window.Epoch.MixDigest()),
+			SuggestedFeeRecipient: ab.config.FeeRecipientAddress,
+			Transactions:          txns,
+			NoTxPool:              false,
+		})
+	}
+	return out, nil
+}
+
+// NewPipeline composes the derivation stages on top of the given L1 traversal.
+func NewPipeline(config *rollup.Config, l1 L1Traversal) AttributesBuilder {
+	return NewBatchAttributesBuilder(config, NewEpochBatchQueue(config, NewL1BatchExtractor(config, l1)))
+}
+
+// DeriveAttributes is the reference derivation: a pure function that derives the payload attributes of all L2 blocks
+// of every epoch with a complete sequencing window in the given contiguous range of L1 inputs,
+// on top of the given L2 parent.
+func DeriveAttributes(config *rollup.Config, parent L2Parent, inputs []*L1Input) ([]*EpochAttributes, error) {
+	pipeline := NewPipeline(config, NewL1InputTraversal(inputs, config.SeqWindowSize))
+	var out []*EpochAttributes
+	for {
+		epochAttrs, err := pipeline.NextAttributes(context.Background(), parent)
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		} else if err != nil {
+			return nil, err
+		}
+		for _, attrs := range epochAttrs.Attributes {
+			parent = parent.Next(epochAttrs.Epoch, uint64(attrs.Timestamp))
+		}
+		out = append(out, epochAttrs)
+	}
+}
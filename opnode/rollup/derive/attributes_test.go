@@ -0,0 +1,367 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of the derivation tests")
+
+// pipelineFixture builds a deterministic L1 chain with deposits and batches to derive L2 blocks from
+type pipelineFixture struct {
+	t      *testing.T
+	rng    *rand.Rand
+	config *rollup.Config
+	key    *ecdsa.PrivateKey
+	inputs []*L1Input
+}
+
+func randomKey(t *testing.T, rng *rand.Rand) *ecdsa.PrivateKey {
+	var seed [32]byte
+	rng.Read(seed[:])
+	key, err := crypto.ToECDSA(seed[:])
+	require.NoError(t, err)
+	return key
+}
+
+func newPipelineFixture(t *testing.T, seed int64) *pipelineFixture {
+	rng := rand.New(rand.NewSource(seed))
+	key := randomKey(t, rng)
+	genesisL1 := eth.BlockID{Hash: randomHash(rng), Number: 100}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1:     genesisL1,
+			L2:     eth.BlockID{Hash: randomHash(rng), Number: 0},
+			L2Time: 1_000_000,
+		},
+		BlockTime:            2,
+		MaxSequencerTimeDiff: 10,
+		SeqWindowSize:        3,
+		L1ChainID:            big.NewInt(900),
+		FeeRecipientAddress:  common.Address{0xff, 0x01},
+		BatchInboxAddress:    common.Address{0xff, 0x02},
+		BatchSenderAddress:   crypto.PubkeyToAddress(key.PublicKey),
+		L1FeeOverhead:        2100,
+		L1FeeScalar:          1_000_000,
+		Forks:                rollup.ForkTimes{rollup.L1InfoV1Fork: 1_000_000},
+	}
+	return &pipelineFixture{t: t, rng: rng, config: cfg, key: key}
+}
+
+// addL1Block appends an empty L1 block, 6 seconds after the previous one
+func (f *pipelineFixture) addL1Block() *L1Input {
+	num, time := f.config.Genesis.L1.Number+1, f.config.Genesis.L2Time+6
+	if n := len(f.inputs); n > 0 {
+		num, time = f.inputs[n-1].Info.NumberU64()+1, f.inputs[n-1].Info.Time()+6
+	}
+	in := &L1Input{Info: &l1MockInfo{
+		num:       num,
+		time:      time,
+		hash:      randomHash(f.rng),
+		baseFee:   big.NewInt(f.rng.Int63n(100 * 1e9)),
+		mixDigest: randomHash(f.rng),
+	}}
+	f.inputs = append(f.inputs, in)
+	return in
+}
+
+// addDeposit adds a successful receipt with a small deposit to the L1 block
+func (f *pipelineFixture) addDeposit(in *L1Input) {
+	data := make([]byte, f.rng.Intn(8))
+	f.rng.Read(data)
+	to := GenerateAddress(f.rng)
+	dep := &types.DepositTx{
+		From:  GenerateAddress(f.rng),
+		To:    &to,
+		Mint:  big.NewInt(f.rng.Int63n(1e9) + 1),
+		Value: big.NewInt(f.rng.Int63n(1e9)),
+		Gas:   uint64(f.rng.Int63n(1e6)),
+		Data:  data,
+	}
+	in.Receipts = append(in.Receipts, &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		Logs:   []*types.Log{GenerateDepositLog(dep)},
+	})
+}
+
+// addBatchTx adds a batch submission transaction, signed by the given key, to the L1 block
+func (f *pipelineFixture) addBatchTx(in *L1Input, key *ecdsa.PrivateKey, batches ...*BatchData) {
+	var buf bytes.Buffer
+	require.NoError(f.t, EncodeBatches(f.config, batches, &buf))
+	tx := types.MustSignNewTx(key, f.config.L1Signer(), &types.DynamicFeeTx{
+		ChainID:   f.config.L1ChainID,
+		Nonce:     uint64(len(in.Transactions)),
+		To:        &f.config.BatchInboxAddress,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1e9),
+		Gas:       100_000,
+		Data:      buf.Bytes(),
+	})
+	in.Transactions = append(in.Transactions, tx)
+}
+
+func (f *pipelineFixture) batch(epoch *L1Input, timestamp uint64, txCount int) *BatchData {
+	var txs []hexutil.Bytes
+	for i := 0; i < txCount; i++ {
+		tx := make([]byte, 1+f.rng.Intn(16))
+		f.rng.Read(tx)
+		txs = append(txs, tx)
+	}
+	return NewBatchData(f.config, epoch.ID(), timestamp, txs)
+}
+
+func (f *pipelineFixture) genesisParent() L2Parent {
+	return L2Parent{Time: f.config.Genesis.L2Time, L1Origin: f.config.Genesis.L1}
+}
+
+type goldenTest struct {
+	name  string
+	build func(f *pipelineFixture)
+}
+
+// TestDeriveAttributesGolden runs the reference derivation over in-memory L1 fixtures,
+// and compares the resulting payload attributes against the golden files in testdata/golden.
+// Run with -update to regenerate the golden files after an intentional change of the derivation.
+func TestDeriveAttributesGolden(t *testing.T) {
+	testCases := []goldenTest{
+		{"empty_epochs", func(f *pipelineFixture) {
+			for i := 0; i < 5; i++ {
+				f.addL1Block()
+			}
+		}},
+		{"deposits", func(f *pipelineFixture) {
+			for i := 0; i < 5; i++ {
+				in := f.addL1Block()
+				for j := 0; j < i; j++ {
+					f.addDeposit(in)
+				}
+			}
+		}},
+		{"batches", func(f *pipelineFixture) {
+			epoch := f.addL1Block()
+			f.addDeposit(epoch)
+			next := f.addL1Block()
+			l2Time := f.config.Genesis.L2Time
+			// batches of the first epoch, submitted in the next L1 block, out of order
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time+4, 2), f.batch(epoch, l2Time+2, 1))
+			f.addL1Block()
+			f.addL1Block()
+		}},
+		{"invalid_batches", func(f *pipelineFixture) {
+			epoch := f.addL1Block()
+			next := f.addL1Block()
+			l2Time := f.config.Genesis.L2Time
+			otherKey := randomKey(t, f.rng)
+			f.addBatchTx(next, otherKey, f.batch(epoch, l2Time+2, 1)) // unauthorized sender
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time+3, 1))    // not aligned with block time
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time, 1))      // not after the L2 parent
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time+6, 1))    // not before the L1 origin time
+			f.addBatchTx(next, f.key, f.batch(next, l2Time+2, 1))     // wrong epoch
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time+4, 1))    // valid
+			f.addBatchTx(next, f.key, f.batch(epoch, l2Time+4, 3))    // duplicate
+			f.addL1Block()
+		}},
+	}
+	for i, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			f := newPipelineFixture(t, int64(1234+i))
+			testCase.build(f)
+			out, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+			require.NoError(t, err)
+			got, err := json.MarshalIndent(out, "", "  ")
+			require.NoError(t, err)
+
+			goldenPath := filepath.Join("testdata", "golden", testCase.name+".json")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(goldenPath), 0755))
+				require.NoError(t, os.WriteFile(goldenPath, append(got, '\n'), 0644))
+			}
+			expected, err := os.ReadFile(goldenPath)
+			require.NoError(t, err, "missing golden file, run with -update to create it")
+			require.JSONEq(t, string(expected), string(got))
+		})
+	}
+}
+
+// TestDeriveAttributesDeterministic checks the reference derivation does not depend on anything but its inputs
+func TestDeriveAttributesDeterministic(t *testing.T) {
+	f := newPipelineFixture(t, 42)
+	for i := 0; i < 6; i++ {
+		in := f.addL1Block()
+		f.addDeposit(in)
+		if i > 0 {
+			prev := f.inputs[i-1]
+			f.addBatchTx(in, f.key, f.batch(prev, prev.Info.Time()-2, 2))
+		}
+	}
+	a, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	b, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	require.Equal(t, a, b)
+	require.Len(t, a, len(f.inputs)-int(f.config.SeqWindowSize)+1, "one epoch per complete sequencing window")
+}
+
+func TestL1InputTraversal(t *testing.T) {
+	f := newPipelineFixture(t, 1234)
+	for i := 0; i < 4; i++ {
+		f.addL1Block()
+	}
+	tr := NewL1InputTraversal(f.inputs, 3)
+	for i := 0; i < 2; i++ {
+		window, err := tr.NextWindow(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, f.inputs[i].ID(), window.EpochID())
+	}
+	_, err := tr.NextWindow(context.Background())
+	require.True(t, errors.Is(err, io.EOF))
+
+	t.Run("not contiguous", func(t *testing.T) {
+		gap := []*L1Input{f.inputs[0], f.inputs[2], f.inputs[3]}
+		_, err := NewL1InputTraversal(gap, 3).NextWindow(context.Background())
+		require.Error(t, err)
+	})
+}
+
+// TestSequenceNumbers checks the L1 info deposits count the L2 blocks within each epoch
+func TestSequenceNumbers(t *testing.T) {
+	f := newPipelineFixture(t, 1234)
+	for i := 0; i < 5; i++ {
+		f.addL1Block()
+	}
+	out, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	require.NotEmpty(t, out)
+	for _, epochAttrs := range out {
+		require.NotEmpty(t, epochAttrs.Attributes)
+		for i, attrs := range epochAttrs.Attributes {
+			var tx types.Transaction
+			require.NoError(t, tx.UnmarshalBinary(attrs.Transactions[0]))
+			info, err := L1InfoDepositTxData(tx.Data())
+			require.NoError(t, err)
+			require.Equal(t, epochAttrs.Epoch, eth.BlockID{Hash: info.BlockHash, Number: info.Number})
+			require.Equal(t, uint64(i), info.SequenceNumber)
+		}
+	}
+}
+
+// TestForkTransition derives L2 blocks across the L1 info and batch forks, and checks that the derivation switches
+// the L1 info version and only accepts batches of the version that is active at their timestamp.
+func TestForkTransition(t *testing.T) {
+	f := newPipelineFixture(t, 1234)
+	l2Time := f.config.Genesis.L2Time
+	f.config.Forks = rollup.ForkTimes{
+		rollup.L1InfoV1Fork: l2Time + 4,
+		rollup.BatchV2Fork:  l2Time + 8,
+	}
+	epoch := f.addL1Block()
+	next := f.addL1Block()
+	f.addBatchTx(next, f.key, f.batch(epoch, l2Time+2, 1), f.batch(epoch, l2Time+4, 2))
+	last := f.addL1Block()
+
+	// v2 batch before the fork
+	early := f.batch(next, l2Time+6, 5)
+	early.Version, early.EpochHash = BatchV2Type, next.Info.Hash()
+	f.addBatchTx(last, f.key, early)
+	f.addBatchTx(last, f.key, f.batch(next, l2Time+6, 1)) // v1 batch before the fork
+	f.addBatchTx(last, f.key, f.batch(next, l2Time+8, 2)) // v2 batch after the fork
+	// v1 batch after the fork
+	late := f.batch(next, l2Time+10, 3)
+	late.Version, late.EpochHash = BatchV1Type, common.Hash{}
+	f.addBatchTx(last, f.key, late)
+	// v2 batch of another L1 chain
+	otherChain := f.batch(next, l2Time+10, 4)
+	otherChain.EpochHash = randomHash(f.rng)
+	f.addBatchTx(last, f.key, otherChain)
+	f.addL1Block()
+
+	out, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	require.Len(t, out, 2)
+
+	expectedTxs := map[uint64]int{l2Time + 2: 1, l2Time + 4: 2, l2Time + 6: 1, l2Time + 8: 2, l2Time + 10: 0}
+	for _, epochAttrs := range out {
+		for _, attrs := range epochAttrs.Attributes {
+			timestamp := uint64(attrs.Timestamp)
+			require.Len(t, attrs.Transactions, 1+expectedTxs[timestamp], "batch transactions at %d", timestamp)
+			delete(expectedTxs, timestamp)
+
+			var tx types.Transaction
+			require.NoError(t, tx.UnmarshalBinary(attrs.Transactions[0]))
+			info, err := L1InfoDepositTxData(tx.Data())
+			require.NoError(t, err)
+			if timestamp < l2Time+4 {
+				require.Equal(t, uint8(L1InfoV0), info.Version, "L1 info at %d", timestamp)
+			} else {
+				require.Equal(t, uint8(L1InfoV1), info.Version, "L1 info at %d", timestamp)
+			}
+		}
+	}
+	require.Empty(t, expectedTxs, "all L2 blocks are derived")
+}
+
+// TestBatcherRotation derives L2 blocks across an update of the batcher schedule, and checks that only batches of
+// the senders that are authorized in the L1 block that the batch is submitted in are accepted.
+func TestBatcherRotation(t *testing.T) {
+	f := newPipelineFixture(t, 1234)
+	l2Time := f.config.Genesis.L2Time
+	newKey := randomKey(t, f.rng)
+	newSender := crypto.PubkeyToAddress(newKey.PublicKey)
+	rotation := f.config.Genesis.L1.Number + 3
+	f.config.BatcherSchedule = []rollup.BatcherUpdate{{L1Block: rotation, Senders: []common.Address{newSender}}}
+
+	epoch := f.addL1Block()
+	next := f.addL1Block()
+	f.addBatchTx(next, f.key, f.batch(epoch, l2Time+2, 1))  // old sender before the rotation
+	f.addBatchTx(next, newKey, f.batch(epoch, l2Time+4, 2)) // new sender before the rotation
+	last := f.addL1Block()
+	require.Equal(t, rotation, last.Info.NumberU64())
+	f.addBatchTx(last, f.key, f.batch(next, l2Time+6, 3))  // old sender after the rotation
+	f.addBatchTx(last, newKey, f.batch(next, l2Time+8, 1)) // new sender after the rotation
+	f.addL1Block()
+	f.addL1Block()
+
+	out, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	require.Len(t, out, 3)
+
+	expectedTxs := map[uint64]int{l2Time + 2: 1, l2Time + 4: 0, l2Time + 6: 0, l2Time + 8: 1, l2Time + 10: 0}
+	for _, epochAttrs := range out {
+		for _, attrs := range epochAttrs.Attributes {
+			timestamp := uint64(attrs.Timestamp)
+			var tx types.Transaction
+			require.NoError(t, tx.UnmarshalBinary(attrs.Transactions[0]))
+			info, err := L1InfoDepositTxData(tx.Data())
+			require.NoError(t, err)
+			if epochAttrs.Epoch.Number < rotation {
+				require.Equal(t, f.config.BatchSenderAddress, info.BatcherAddr)
+			} else {
+				require.Equal(t, newSender, info.BatcherAddr)
+			}
+
+			if expected, ok := expectedTxs[timestamp]; ok {
+				require.Len(t, attrs.Transactions, 1+expected, "batch transactions at %d", timestamp)
+				delete(expectedTxs, timestamp)
+			}
+		}
+	}
+	require.Empty(t, expectedTxs, "all L2 blocks are derived")
+}
@@ -2,12 +2,15 @@ package derive
 
 import (
 	"bytes"
+	"compress/zlib"
 	"errors"
 	"fmt"
 	"io"
 	"sync"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -17,17 +20,21 @@ import (
 //
 // BatchV1Type := 0
 // batchV1 := BatchV1Type ++ RLP([epoch, timestamp, transaction_list]
+// BatchV2Type := 1
+// batchV2 := BatchV2Type ++ RLP([epoch, epoch_hash, timestamp, transaction_list]
 //
 // An empty input is not a valid batch.
+// The batches of L2 blocks before the rollup.BatchV2Fork are V1 batches, later batches are V2 batches.
 //
 // Batch-bundle format
 // first byte is type followed by bytestring
 //
 // payload := RLP([batch_0, batch_1, ..., batch_N])
 // bundleV1 := BatchBundleV1Type ++ payload
-// bundleV2 := BatchBundleV2Type ++ compress(payload)  # TODO: compressed bundle of batches
+// bundleV2 := BatchBundleV2Type ++ zlib_compress(payload)
 //
 // An empty input is not a valid bundle.
+// V1 bundles contain only V1 batches, V2 bundles only V2 batches.
 //
 // Note: the type system is based on L1 typed transactions.
 
@@ -38,6 +45,7 @@ var encodeBufferPool = sync.Pool{
 
 const (
 	BatchV1Type = iota
+	BatchV2Type
 )
 
 const (
@@ -45,6 +53,9 @@ const (
 	BatchBundleV2Type
 )
 
+// MaxBundleSize limits the size of the decompressed payload of a v2 bundle
+const MaxBundleSize = 10_000_000
+
 type BatchV1 struct {
 	Epoch     rollup.Epoch // aka l1 num
 	Timestamp uint64
@@ -52,9 +63,53 @@ type BatchV1 struct {
 	Transactions []hexutil.Bytes
 }
 
+type BatchV2 struct {
+	Epoch rollup.Epoch // aka l1 num
+	// EpochHash is the hash of the L1 block of the epoch, the batch is invalid on any other L1 chain
+	EpochHash    common.Hash
+	Timestamp    uint64
+	Transactions []hexutil.Bytes
+}
+
 type BatchData struct {
 	BatchV1
 	// batches may contain additional data with new upgrades
+
+	// Version is the batch type that the batch is encoded with
+	Version uint8
+	// EpochHash is the hash of the L1 block of the epoch, only encoded in BatchV2Type batches
+	EpochHash common.Hash
+}
+
+// NewBatchData creates the batch of the L2 block with the given L1 origin and timestamp,
+// in the batch version of the forks that are active at the timestamp.
+func NewBatchData(config *rollup.Config, epoch eth.BlockID, timestamp uint64, txs []hexutil.Bytes) *BatchData {
+	batch := &BatchData{
+		BatchV1: BatchV1{
+			Epoch:        rollup.Epoch(epoch.Number),
+			Timestamp:    timestamp,
+			Transactions: txs,
+		},
+		Version: BatchVersion(config, timestamp),
+	}
+	if batch.Version == BatchV2Type {
+		batch.EpochHash = epoch.Hash
+	}
+	return batch
+}
+
+// BatchVersion returns the batch type of the L2 block with the given timestamp.
+func BatchVersion(config *rollup.Config, l2Time uint64) uint8 {
+	if config.IsActive(rollup.BatchV2Fork, l2Time) {
+		return BatchV2Type
+	}
+	return BatchV1Type
+}
+
+// bundleTypes maps the batch types to the bundle type that contains them
+var bundleTypes = map[uint8]byte{
+	BatchV1Type: BatchBundleV1Type,
+	BatchV2Type: BatchBundleV2Type,
 }
 
 func DecodeBatches(config *rollup.Config, r io.Reader) ([]*BatchData, error) {
@@ -62,24 +117,48 @@ func DecodeBatches(config *rollup.Config, r io.Reader) ([]*BatchData, error) {
 	if _, err := io.ReadFull(r, typeData[:]); err != nil {
 		return nil, fmt.Errorf("failed to read batch bundle type byte: %v", err)
 	}
+	var out []*BatchData
 	switch typeData[0] {
 	case BatchBundleV1Type:
-		var out []*BatchData
 		if err := rlp.Decode(r, &out); err != nil {
 			return nil, fmt.Errorf("failed to decode v1 batches list: %v", err)
 		}
-		return out, nil
 	case BatchBundleV2Type:
-		// TODO: implement compression of a bundle of batches
-		return nil, errors.New("bundle v2 not supported yet")
+		zr, err := zlib.NewReader(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress v2 bundle: %v", err)
+		}
+		defer zr.Close()
+		if err := rlp.NewStream(zr, MaxBundleSize).Decode(&out); err != nil {
+			return nil, fmt.Errorf("failed to decode v2 batches list: %v", err)
+		}
 	default:
 		return nil, fmt.Errorf("unrecognized batch bundle type: %d", typeData[0])
 	}
+	for i, batch := range out {
+		if bundleTypes[batch.Version] != typeData[0] {
+			return nil, fmt.Errorf("batch %d of type %d cannot be in bundle of type %d", i, batch.Version, typeData[0])
+		}
+	}
+	return out, nil
 }
 
+// EncodeBatches encodes the batches as a bundle. The bundle type follows the version of the batches:
+// v1 batches are bundled without compression, v2 batches in a compressed bundle.
+// Batches of different versions must be submitted in separate bundles.
 func EncodeBatches(config *rollup.Config, batches []*BatchData, w io.Writer) error {
-	// default to encode as v1 (no compression). Config may change this in the future.
 	bundleType := byte(BatchBundleV1Type)
+	for i, batch := range batches {
+		typ, ok := bundleTypes[batch.Version]
+		if !ok {
+			return fmt.Errorf("unrecognized batch type: %d", batch.Version)
+		}
+		if i > 0 && typ != bundleType {
+			return fmt.Errorf("batch %d of type %d cannot be bundled with batches of type %d",
+				i, batch.Version, batches[0].Version)
+		}
+		bundleType = typ
+	}
 
 	if _, err := w.Write([]byte{bundleType}); err != nil {
 		return fmt.Errorf("failed to encode batch type")
@@ -91,7 +170,14 @@ func EncodeBatches(config *rollup.Config, batches []*BatchData, w io.Writer) err
 		}
 		return nil
 	case BatchBundleV2Type:
-		return errors.New("bundle v2 not supported yet")
+		zw := zlib.NewWriter(w)
+		if err := rlp.Encode(zw, batches); err != nil {
+			return fmt.Errorf("failed to encode RLP-list payload of v2 bundle: %v", err)
+		}
+		if err := zw.Close(); err != nil {
+			return fmt.Errorf("failed to compress v2 bundle: %v", err)
+		}
+		return nil
 	default:
 		return fmt.Errorf("unrecognized batch bundle type: %d", bundleType)
 	}
@@ -116,8 +202,21 @@ func (b *BatchData) MarshalBinary() ([]byte, error) {
 }
 
 func (b *BatchData) encodeTyped(buf *bytes.Buffer) error {
-	buf.WriteByte(BatchV1Type)
-	return rlp.Encode(buf, &b.BatchV1)
+	switch b.Version {
+	case BatchV1Type:
+		buf.WriteByte(BatchV1Type)
+		return rlp.Encode(buf, &b.BatchV1)
+	case BatchV2Type:
+		buf.WriteByte(BatchV2Type)
+		return rlp.Encode(buf, &BatchV2{
+			Epoch:        b.Epoch,
+			EpochHash:    b.EpochHash,
+			Timestamp:    b.Timestamp,
+			Transactions: b.Transactions,
+		})
+	default:
+		return fmt.Errorf("unrecognized batch type: %d", b.Version)
+	}
 }
 
 // DecodeRLP implements rlp.Decoder
@@ -146,7 +245,23 @@ func (b *BatchData) decodeTyped(data []byte) error {
 	}
 	switch data[0] {
 	case BatchV1Type:
+		*b = BatchData{Version: BatchV1Type}
 		return rlp.DecodeBytes(data[1:], &b.BatchV1)
+	case BatchV2Type:
+		var v2 BatchV2
+		if err := rlp.DecodeBytes(data[1:], &v2); err != nil {
+			return err
+		}
+		*b = BatchData{
+			BatchV1: BatchV1{
+				Epoch:        v2.Epoch,
+				Timestamp:    v2.Timestamp,
+				Transactions: v2.Transactions,
+			},
+			Version:   BatchV2Type,
+			EpochHash: v2.EpochHash,
+		}
+		return nil
 	default:
 		return fmt.Errorf("unrecognized batch type: %d", data[0])
 	}
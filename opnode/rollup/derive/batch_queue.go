@@ -0,0 +1,102 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+)
+
+// L2Parent is the part of the L2 chain state that the derivation of the next L2 blocks depends on.
+type L2Parent struct {
+	Time           uint64      `json:"time"`
+	L1Origin       eth.BlockID `json:"l1_origin"`
+	SequenceNumber uint64      `json:"sequence_number"`
+}
+
+// Next returns the L2 parent state after adding a block with the given L1 origin and timestamp.
+func (p L2Parent) Next(l1Origin eth.BlockID, time uint64) L2Parent {
+	seqNumber := uint64(0)
+	if p.L1Origin == l1Origin {
+		seqNumber = p.SequenceNumber + 1
+	}
+	return L2Parent{Time: time, L1Origin: l1Origin, SequenceNumber: seqNumber}
+}
+
+// EpochBatches are the batches found in the sequencing window of an epoch
+type EpochBatches struct {
+	Window  *SequencingWindow
+	Batches []*BatchData
+}
+
+// BatchExtractor is the second stage of the derivation: it reads batches from the L1 transactions of each window.
+type BatchExtractor interface {
+	// NextBatches returns all candidate batches submitted in the sequencing window of the next epoch, in L1 order.
+	// Only batches of the senders that are authorized in the L1 block that they are submitted in are candidates.
+	// The batches have not been validated against the L2 chain yet.
+	NextBatches(ctx context.Context) (*EpochBatches, error)
+}
+
+// L1BatchExtractor extracts batches from the batch inbox transactions of the L1 traversal.
+type L1BatchExtractor struct {
+	config *rollup.Config
+	l1     L1Traversal
+}
+
+var _ BatchExtractor = (*L1BatchExtractor)(nil)
+
+func NewL1BatchExtractor(config *rollup.Config, l1 L1Traversal) *L1BatchExtractor {
+	return &L1BatchExtractor{config: config, l1: l1}
+}
+
+func (be *L1BatchExtractor) NextBatches(ctx context.Context) (*EpochBatches, error) {
+	window, err := be.l1.NextWindow(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var batches []*BatchData
+	for i, txs := range window.Transactions {
+		l1Num := window.Epoch.NumberU64() + uint64(i)
+		blockBatches, err := BatchesFromEVMTransactions(be.config, l1Num, txs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract batches from L1 block %d in window of epoch %s: %w",
+				l1Num, window.EpochID(), err)
+		}
+		batches = append(batches, blockBatches...)
+	}
+	return &EpochBatches{Window: window, Batches: batches}, nil
+}
+
+// BatchQueue is the third stage of the derivation: it orders the batches of an epoch into
+// one batch per L2 block, on top of the given L2 parent.
+type BatchQueue interface {
+	// NextBatches returns the contiguous batches of the next epoch, starting right after the L2 parent.
+	// Invalid and duplicate batches are dropped, and gaps are filled with empty batches.
+	NextBatches(ctx context.Context, parent L2Parent) (*EpochBatches, error)
+}
+
+// EpochBatchQueue filters and sorts the batches of one epoch at a time.
+type EpochBatchQueue struct {
+	config *rollup.Config
+	src    BatchExtractor
+}
+
+var _ BatchQueue = (*EpochBatchQueue)(nil)
+
+func NewEpochBatchQueue(config *rollup.Config, src BatchExtractor) *EpochBatchQueue {
+	return &EpochBatchQueue{config: config, src: src}
+}
+
+func (bq *EpochBatchQueue) NextBatches(ctx context.Context, parent L2Parent) (*EpochBatches, error) {
+	candidates, err := bq.src.NextBatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+	epoch := candidates.Window.EpochID()
+	minL2Time := parent.Time + bq.config.BlockTime
+	maxL2Time := candidates.Window.Epoch.Time()
+	batches := FilterBatches(bq.config, epoch, minL2Time, maxL2Time, candidates.Batches)
+	batches = SortedAndPreparedBatches(batches, epoch.Number, bq.config.BlockTime, minL2Time, maxL2Time)
+	return &EpochBatches{Window: candidates.Window, Batches: batches}, nil
+}
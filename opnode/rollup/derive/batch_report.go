@@ -0,0 +1,52 @@
+package derive
+
+import (
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+)
+
+// EpochBatchReport explains the batches of an epoch: every candidate batch of the sequencing window, with the reason
+// that it was rejected, and the resulting batch of every L2 block of the epoch.
+type EpochBatchReport struct {
+	Epoch eth.BlockID
+	// The L2 blocks of the epoch have a timestamp in [MinL2Time, MaxL2Time)
+	MinL2Time uint64
+	MaxL2Time uint64
+	// Candidates in L1 order. The candidates without error are the batches of the Plan.
+	Candidates []*BatchCandidate
+	// Plan is the batch of every L2 block of the epoch, see SortedAndPreparedBatches.
+	// L2 blocks without candidate have an empty batch.
+	Plan []*BatchData
+}
+
+// ReportBatches runs the batch extraction and batch queue of the derivation over the sequencing window,
+// on top of the L2 parent, and reports the fate of every candidate batch.
+func ReportBatches(config *rollup.Config, parent L2Parent, window *SequencingWindow) *EpochBatchReport {
+	report := &EpochBatchReport{
+		Epoch:     window.EpochID(),
+		MinL2Time: parent.Time + config.BlockTime,
+		MaxL2Time: window.Epoch.Time(),
+	}
+	var decoded []*BatchCandidate
+	var batches []*BatchData
+	for i, txs := range window.Transactions {
+		for _, candidate := range BatchCandidates(config, window.Epoch.NumberU64()+uint64(i), txs) {
+			report.Candidates = append(report.Candidates, candidate)
+			if candidate.Err == nil {
+				decoded = append(decoded, candidate)
+				batches = append(batches, candidate.Batch)
+			}
+		}
+	}
+	var accepted []*BatchData
+	for i, err := range CheckBatches(config, report.Epoch, report.MinL2Time, report.MaxL2Time, batches) {
+		if err != nil {
+			decoded[i].Err = err
+			continue
+		}
+		accepted = append(accepted, batches[i])
+	}
+	report.Plan = SortedAndPreparedBatches(accepted, report.Epoch.Number, config.BlockTime,
+		report.MinL2Time, report.MaxL2Time)
+	return report
+}
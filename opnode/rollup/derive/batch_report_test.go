@@ -0,0 +1,80 @@
+package derive
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportBatches(t *testing.T) {
+	f := newPipelineFixture(t, 1234)
+	epoch := f.addL1Block()
+	next := f.addL1Block()
+	l2Time := f.config.Genesis.L2Time
+	f.addBatchTx(next, randomKey(t, f.rng), f.batch(epoch, l2Time+2, 1))
+	// not a bundle
+	next.Transactions = append(next.Transactions, types.MustSignNewTx(f.key, f.config.L1Signer(), &types.DynamicFeeTx{
+		ChainID:   f.config.L1ChainID,
+		Nonce:     uint64(len(next.Transactions)),
+		To:        &f.config.BatchInboxAddress,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1e9),
+		Gas:       100_000,
+		Data:      []byte{0x42},
+	}))
+	f.addBatchTx(next, f.key,
+		f.batch(epoch, l2Time+3, 1),
+		f.batch(epoch, l2Time, 1),
+		f.batch(epoch, l2Time+6, 1),
+		f.batch(next, l2Time+2, 1),
+		f.batch(epoch, l2Time+4, 2),
+		f.batch(epoch, l2Time+4, 3))
+	f.addL1Block()
+
+	window, err := NewL1InputTraversal(f.inputs, f.config.SeqWindowSize).NextWindow(context.Background())
+	require.NoError(t, err)
+	report := ReportBatches(f.config, f.genesisParent(), window)
+	require.Equal(t, epoch.ID(), report.Epoch)
+	require.Equal(t, l2Time+2, report.MinL2Time)
+	require.Equal(t, epoch.Info.Time(), report.MaxL2Time)
+
+	reasons := []string{
+		"unauthorized sender",
+		"failed to decode bundle",
+		"bad timestamp",
+		"too old",
+		"too new",
+		"wrong epoch",
+		"",
+		"duplicate",
+	}
+	require.Len(t, report.Candidates, len(reasons))
+	for i, candidate := range report.Candidates {
+		require.Equal(t, next.Info.NumberU64(), candidate.L1Block)
+		if reasons[i] == "" {
+			require.NoError(t, candidate.Err, "candidate %d", i)
+			continue
+		}
+		require.Error(t, candidate.Err, "candidate %d", i)
+		require.True(t, strings.HasPrefix(candidate.Err.Error(), reasons[i]), "candidate %d: %v", i, candidate.Err)
+	}
+	require.Nil(t, report.Candidates[1].Batch)
+	require.Equal(t, 5, report.Candidates[7].Index)
+
+	// the plan is the same as the batches of the derivation
+	require.Len(t, report.Plan, 2)
+	require.Equal(t, l2Time+2, report.Plan[0].Timestamp)
+	require.Empty(t, report.Plan[0].Transactions)
+	require.Same(t, report.Candidates[6].Batch, report.Plan[1])
+	out, err := DeriveAttributes(f.config, f.genesisParent(), f.inputs)
+	require.NoError(t, err)
+	require.Len(t, out[0].Attributes, len(report.Plan))
+	for i, attrs := range out[0].Attributes {
+		require.Equal(t, report.Plan[i].Timestamp, uint64(attrs.Timestamp))
+		require.Len(t, attrs.Transactions, 1+len(report.Plan[i].Transactions))
+	}
+}
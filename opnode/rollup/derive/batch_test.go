@@ -2,11 +2,15 @@ package derive
 
 import (
 	"bytes"
+	"compress/zlib"
 	"testing"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +47,46 @@ func TestBatchRoundTrip(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, batches, out)
 }
+
+func TestBatchV2RoundTrip(t *testing.T) {
+	config := &rollup.Config{Forks: rollup.ForkTimes{rollup.L1InfoV1Fork: 0, rollup.BatchV2Fork: 1000}}
+	epoch := eth.BlockID{Hash: common.Hash{0x42}, Number: 1}
+	v1 := NewBatchData(config, epoch, 998, []hexutil.Bytes{{0x01}})
+	assert.Equal(t, uint8(BatchV1Type), v1.Version)
+	assert.Equal(t, common.Hash{}, v1.EpochHash, "v1 batches do not commit to the epoch hash")
+	v2 := NewBatchData(config, epoch, 1000, []hexutil.Bytes{{0x01}, make([]byte, 1000)})
+	assert.Equal(t, uint8(BatchV2Type), v2.Version)
+	assert.Equal(t, epoch.Hash, v2.EpochHash)
+
+	enc, err := v2.MarshalBinary()
+	assert.NoError(t, err)
+	assert.Equal(t, byte(BatchV2Type), enc[0])
+	var dec BatchData
+	assert.NoError(t, dec.UnmarshalBinary(enc))
+	assert.Equal(t, v2, &dec)
+
+	var buf bytes.Buffer
+	assert.NoError(t, EncodeBatches(config, []*BatchData{v2, v2}, &buf))
+	assert.Equal(t, byte(BatchBundleV2Type), buf.Bytes()[0])
+	assert.Less(t, buf.Len(), 2*len(enc), "v2 bundles are compressed")
+	out, err := DecodeBatches(config, &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, []*BatchData{v2, v2}, out)
+
+	// batches of the two versions cannot be bundled together
+	assert.Error(t, EncodeBatches(config, []*BatchData{v1, v2}, &buf))
+
+	// a v1 bundle cannot contain v2 batches, and the other way around
+	var v1Bundle bytes.Buffer
+	v1Bundle.WriteByte(BatchBundleV1Type)
+	assert.NoError(t, rlp.Encode(&v1Bundle, []*BatchData{v2}))
+	_, err = DecodeBatches(config, &v1Bundle)
+	assert.Error(t, err)
+	var v2Bundle bytes.Buffer
+	v2Bundle.WriteByte(BatchBundleV2Type)
+	zw := zlib.NewWriter(&v2Bundle)
+	assert.NoError(t, rlp.Encode(zw, []*BatchData{v1}))
+	assert.NoError(t, zw.Close())
+	_, err = DecodeBatches(config, &v2Bundle)
+	assert.Error(t, err)
+}
@@ -3,11 +3,14 @@
 // turned back into L1 data.
 //
 // The flow is data is as follows
-// receipts -> l2.PayloadAttributes with `payload_attributes.go`
+// L1 blocks -> sequencing windows with the L1 traversal in `l1_traversal.go`
+// sequencing windows -> batches with the batch extraction and batch queue in `batch_queue.go`
+// batches -> l2.PayloadAttributes with the attributes builder in `attributes.go`
+// receipts -> deposits with `payload_attributes.go`
 // l2.PayloadAttributes -> l2.ExecutionPayload with `execution_payload.go`
 // L2 block -> Corresponding L1 block with `invert.go`
 //
-// The Payload Atrribute derivation stage is a pure function.
+// The Payload Atrribute derivation stage is a pure function, see DeriveAttributes.
 // The Execution Payload derivation stage relies on the L2 execution engine to perform the
 // state update.
 // The inversion step is a pure function.
@@ -0,0 +1,173 @@
+package derive
+
+import (
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/deposit"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+// depositsEqual compares deposits by value, big.Int fields are compared numerically.
+func depositsEqual(a, b *types.DepositTx) bool {
+	bigEq := func(x, y *big.Int) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+		return x.Cmp(y) == 0
+	}
+	return a.BlockHeight == b.BlockHeight &&
+		a.TransactionIndex == b.TransactionIndex &&
+		a.From == b.From &&
+		reflect.DeepEqual(a.To, b.To) &&
+		bigEq(a.Mint, b.Mint) &&
+		bigEq(a.Value, b.Value) &&
+		a.Gas == b.Gas &&
+		string(a.Data) == string(b.Data)
+}
+
+// randomDeposit implements quick.Generator to feed property-based tests with valid deposits.
+type randomDeposit struct {
+	*types.DepositTx
+}
+
+func (randomDeposit) Generate(rng *rand.Rand, size int) reflect.Value {
+	dep := GenerateDeposit(rng.Uint64(), uint64(rng.Intn(10000)), rng)
+	return reflect.ValueOf(randomDeposit{dep})
+}
+
+// TestDepositLogRoundTrip is a property-based test: any deposit encoded as log event with the deposit contract ABI
+// decodes back into the same deposit, and is understood the same way by the generated deposit bindings.
+func TestDepositLogRoundTrip(t *testing.T) {
+	depositFilterer, err := deposit.NewDepositFilterer(DepositContractAddr, nil)
+	require.NoError(t, err)
+
+	roundTrip := func(in randomDeposit) bool {
+		log, err := MarshalDepositLogEvent(DepositContractAddr, in.DepositTx)
+		if err != nil {
+			t.Logf("failed to encode deposit: %v", err)
+			return false
+		}
+		out, err := UnmarshalLogEvent(in.BlockHeight, in.TransactionIndex, log)
+		if err != nil {
+			t.Logf("failed to decode deposit: %v", err)
+			return false
+		}
+		if !depositsEqual(in.DepositTx, out) {
+			return false
+		}
+		ev, err := depositFilterer.ParseTransactionDeposited(*log)
+		if err != nil {
+			t.Logf("bindings failed to parse deposit log: %v", err)
+			return false
+		}
+		mint := in.Mint
+		if mint == nil {
+			mint = new(big.Int)
+		}
+		return ev.From == in.From &&
+			ev.Mint.Cmp(mint) == 0 &&
+			ev.Value.Cmp(in.Value) == 0 &&
+			ev.GasLimit.Uint64() == in.Gas &&
+			ev.IsCreation == (in.To == nil) &&
+			string(ev.Data) == string(in.Data)
+	}
+	require.NoError(t, quick.Check(roundTrip, &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1234))}))
+}
+
+// FuzzUnmarshalLogEvent checks that arbitrary log data never makes the deposit decoding panic,
+// and that any accepted log is the canonical encoding of the decoded deposit.
+func FuzzUnmarshalLogEvent(f *testing.F) {
+	rng := rand.New(rand.NewSource(1234))
+	for i := 0; i < 10; i++ {
+		log := GenerateDepositLog(GenerateDeposit(rng.Uint64(), uint64(i), rng))
+		f.Add(log.Topics[1].Bytes(), log.Topics[2].Bytes(), log.Data)
+	}
+	f.Add([]byte{}, []byte{}, []byte{})
+	f.Add(make([]byte, 32), make([]byte, 32), make([]byte, 6*32))
+	f.Fuzz(func(t *testing.T, from []byte, to []byte, data []byte) {
+		log := &types.Log{
+			Address: DepositContractAddr,
+			Topics:  []common.Hash{DepositEventABIHash, common.BytesToHash(from), common.BytesToHash(to)},
+			Data:    data,
+		}
+		dep, err := UnmarshalLogEvent(123, 4, log)
+		if err != nil {
+			require.Nil(t, dep)
+			require.NotEmpty(t, err.Error(), "errors must be descriptive")
+			return
+		}
+		reEncoded, err := MarshalDepositLogEvent(DepositContractAddr, dep)
+		require.NoError(t, err)
+		require.Equal(t, log.Topics, reEncoded.Topics, "accepted topics must be canonical")
+		require.Equal(t, log.Data, reEncoded.Data, "accepted data must be canonical")
+	})
+}
+
+// FuzzDepositRoundTrip checks that any valid deposit survives the encoding into and decoding from a log event.
+func FuzzDepositRoundTrip(f *testing.F) {
+	f.Add([]byte{0x01}, []byte{0x02}, []byte{}, []byte{0x03}, uint64(21000), false, []byte{})
+	f.Add([]byte{0x01}, []byte{}, []byte{0x10, 0x00}, []byte{}, uint64(1_000_000), true, []byte{0x60, 0x80, 0x60, 0x40})
+	f.Fuzz(func(t *testing.T, from []byte, to []byte, mint []byte, value []byte, gas uint64, isCreation bool, data []byte) {
+		if len(mint) > 32 || len(value) > 32 {
+			t.Skip("uint256 values cannot be larger than 32 bytes")
+		}
+		dep := &types.DepositTx{
+			BlockHeight:      42,
+			TransactionIndex: 1,
+			From:             common.BytesToAddress(from),
+			Value:            new(big.Int).SetBytes(value),
+			Gas:              gas,
+			Data:             data,
+		}
+		if !isCreation {
+			toAddr := common.BytesToAddress(to)
+			dep.To = &toAddr
+		}
+		if m := new(big.Int).SetBytes(mint); m.Sign() != 0 {
+			dep.Mint = m
+		}
+		log, err := MarshalDepositLogEvent(DepositContractAddr, dep)
+		require.NoError(t, err)
+		out, err := UnmarshalLogEvent(dep.BlockHeight, dep.TransactionIndex, log)
+		require.NoError(t, err)
+		require.True(t, depositsEqual(dep, out), "deposit changed in round trip: %v != %v", dep, out)
+	})
+}
+
+// FuzzL1InfoDepositTxData checks that arbitrary L1 info calldata never makes the decoding panic,
+// and that any accepted calldata is the canonical encoding of the decoded info.
+func FuzzL1InfoDepositTxData(f *testing.F) {
+	rng := rand.New(rand.NewSource(1234))
+	for i := 0; i < 5; i++ {
+		info := randomL1Info(rng)
+		v0 := L1BlockInfo{Version: L1InfoV0, Number: info.num, Time: info.time, BaseFee: info.baseFee, BlockHash: info.hash}
+		data, err := v0.MarshalBinary()
+		require.NoError(f, err)
+		f.Add(data)
+		cfg := &rollup.Config{
+			BatchSenderAddress: common.Address{byte(i)},
+			Forks:              rollup.ForkTimes{rollup.L1InfoV1Fork: 0},
+		}
+		dep, err := L1InfoDeposit(rng.Uint64(), info, 0, cfg)
+		require.NoError(f, err)
+		f.Add(dep.Data)
+	}
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		info, err := L1InfoDepositTxData(data)
+		if err != nil {
+			require.NotEmpty(t, err.Error(), "errors must be descriptive")
+			return
+		}
+		reEncoded, err := info.MarshalBinary()
+		require.NoError(t, err)
+		require.Equal(t, data, reEncoded, "accepted L1 info must be canonical")
+	})
+}
@@ -1,9 +1,7 @@
 package derive
 
 import (
-	"encoding/binary"
 	"fmt"
-	"math/big"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
@@ -11,23 +9,6 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-// L1InfoDepositTxData is the inverse of L1InfoDeposit, to see where the L2 chain is derived from
-func L1InfoDepositTxData(data []byte) (nr uint64, time uint64, baseFee *big.Int, blockHash common.Hash, err error) {
-	if len(data) != 4+8+8+32+32 {
-		err = fmt.Errorf("data is unexpected length: %d", len(data))
-		return
-	}
-	offset := 4
-	nr = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-	time = binary.BigEndian.Uint64(data[offset : offset+8])
-	offset += 8
-	baseFee = new(big.Int).SetBytes(data[offset : offset+32])
-	offset += 32
-	blockHash.SetBytes(data[offset : offset+32])
-	return
-}
-
 type Block interface {
 	Hash() common.Hash
 	NumberU64() uint64
@@ -51,9 +32,14 @@ func BlockReferences(l2Block Block, genesis *rollup.Genesis) (eth.L2BlockRef, er
 	if len(txs) == 0 || txs[0].Type() != types.DepositTxType {
 		return eth.L2BlockRef{}, fmt.Errorf("l2 block is missing L1 info deposit tx, block hash: %s", l2Block.Hash())
 	}
-	l1Number, _, _, l1Hash, err := L1InfoDepositTxData(txs[0].Data())
+	info, err := L1InfoDepositTxData(txs[0].Data())
 	if err != nil {
 		return eth.L2BlockRef{}, fmt.Errorf("failed to parse L1 info deposit tx from L2 block: %v", err)
 	}
-	return eth.L2BlockRef{Self: self, Parent: l2Parent, L1Origin: eth.BlockID{Hash: l1Hash, Number: l1Number}}, nil
+	return eth.L2BlockRef{
+		Self:           self,
+		Parent:         l2Parent,
+		L1Origin:       eth.BlockID{Hash: info.BlockHash, Number: info.Number},
+		SequenceNumber: info.SequenceNumber,
+	}, nil
 }
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
@@ -69,7 +70,6 @@ type infoTest struct {
 }
 
 func TestParseL1InfoDepositTxData(t *testing.T) {
-	// Go 1.18 will have native fuzzing for us to use, until then, we cover just the below cases
 	cases := []infoTest{
 		{"random", makeInfo(nil)},
 		{"zero basefee", makeInfo(func(l *l1MockInfo) {
@@ -85,29 +85,79 @@ func TestParseL1InfoDepositTxData(t *testing.T) {
 			return &l1MockInfo{baseFee: new(big.Int)}
 		}},
 	}
+	cfg := &rollup.Config{
+		BatchSenderAddress: common.Address{0x42},
+		Forks:              rollup.ForkTimes{rollup.L1InfoV1Fork: 1000},
+	}
 	for i, testCase := range cases {
 		t.Run(testCase.name, func(t *testing.T) {
-			info := testCase.mkInfo(rand.New(rand.NewSource(int64(1234 + i))))
-			depTx := L1InfoDeposit(info)
-			nr, time, baseFee, h, err := L1InfoDepositTxData(depTx.Data)
+			rng := rand.New(rand.NewSource(int64(1234 + i)))
+			info := testCase.mkInfo(rng)
+			seqNr := rng.Uint64()
+			depTx, err := L1InfoDeposit(seqNr, info, 1000, cfg)
+			assert.NoError(t, err)
+			res, err := L1InfoDepositTxData(depTx.Data)
 			assert.NoError(t, err, "expected valid deposit info")
-			assert.Equal(t, nr, info.num)
-			assert.Equal(t, time, info.time)
-			assert.True(t, baseFee.Sign() >= 0)
-			assert.Equal(t, baseFee.Bytes(), info.baseFee.Bytes())
-			assert.Equal(t, h, info.hash)
+			assert.Equal(t, uint8(L1InfoV1), res.Version)
+			assert.Equal(t, res.Number, info.num)
+			assert.Equal(t, res.Time, info.time)
+			assert.True(t, res.BaseFee.Sign() >= 0)
+			assert.Equal(t, res.BaseFee.Bytes(), info.baseFee.Bytes())
+			assert.Equal(t, res.BlockHash, info.hash)
+			assert.Equal(t, res.SequenceNumber, seqNr)
+			assert.Equal(t, res.BatcherAddr, cfg.BatchSenderAddress)
 		})
 	}
+	t.Run("before fork", func(t *testing.T) {
+		info := randomL1Info(rand.New(rand.NewSource(42)))
+		depTx, err := L1InfoDeposit(1, info, 999, cfg)
+		assert.NoError(t, err)
+		res, err := L1InfoDepositTxData(depTx.Data)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(L1InfoV0), res.Version)
+		assert.Equal(t, res.Number, info.num)
+		assert.Equal(t, res.BlockHash, info.hash)
+		assert.Zero(t, res.SequenceNumber)
+	})
+	t.Run("legacy v0", func(t *testing.T) {
+		info := randomL1Info(rand.New(rand.NewSource(42)))
+		v0 := L1BlockInfo{Version: L1InfoV0, Number: info.num, Time: info.time, BaseFee: info.baseFee, BlockHash: info.hash}
+		data, err := v0.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Len(t, data, 4+8+8+32+32)
+		res, err := L1InfoDepositTxData(data)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(L1InfoV0), res.Version)
+		assert.Equal(t, res.Number, info.num)
+		assert.Equal(t, res.Time, info.time)
+		assert.Equal(t, res.BaseFee.Bytes(), info.baseFee.Bytes())
+		assert.Equal(t, res.BlockHash, info.hash)
+		assert.Zero(t, res.SequenceNumber)
+	})
 	t.Run("no data", func(t *testing.T) {
-		_, _, _, _, err := L1InfoDepositTxData(nil)
+		_, err := L1InfoDepositTxData(nil)
 		assert.Error(t, err)
 	})
 	t.Run("not enough data", func(t *testing.T) {
-		_, _, _, _, err := L1InfoDepositTxData([]byte{1, 2, 3, 4})
+		_, err := L1InfoDepositTxData([]byte{1, 2, 3, 4})
 		assert.Error(t, err)
 	})
 	t.Run("too much data", func(t *testing.T) {
-		_, _, _, _, err := L1InfoDepositTxData(make([]byte, 4+8+8+32+32+1))
+		data := make([]byte, 4+8+8+32+32+1)
+		copy(data, L1InfoFuncBytes4)
+		_, err := L1InfoDepositTxData(data)
+		assert.Error(t, err)
+	})
+	t.Run("unknown selector", func(t *testing.T) {
+		_, err := L1InfoDepositTxData(make([]byte, 4+8*32))
+		assert.Error(t, err)
+	})
+	t.Run("dirty v1 padding", func(t *testing.T) {
+		info := L1BlockInfo{Version: L1InfoV1, Number: 1, Time: 2, BaseFee: big.NewInt(3)}
+		data, err := info.MarshalBinary()
+		assert.NoError(t, err)
+		data[4+5*32] = 1 // high byte of the padded batcher address
+		_, err = L1InfoDepositTxData(data)
 		assert.Error(t, err)
 	})
 }
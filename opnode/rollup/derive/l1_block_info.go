@@ -0,0 +1,247 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/l1block"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// L1 info format
+// The L1 info deposit is the first transaction of every L2 block, calling the L1Block predeploy.
+// The format is versioned by the function selector of the call:
+//
+// L1InfoV0 := L1InfoFuncBytes4 ++ uint64(number) ++ uint64(timestamp) ++ uint256(basefee) ++ bytes32(hash)
+// L1InfoV1 := ABI encoded setL1BlockValuesV1(number, timestamp, basefee, hash, sequenceNumber, batcher, l1FeeOverhead, l1FeeScalar)
+//
+// Decoding supports all versions. L2 blocks before the rollup.L1InfoV1Fork encode L1InfoV0, later blocks L1InfoV1.
+
+const (
+	L1InfoV0 = iota
+	L1InfoV1
+)
+
+// l1InfoV0Len is the fixed size of the legacy L1 info calldata
+const l1InfoV0Len = 4 + 8 + 8 + 32 + 32
+
+// L1InfoV1Method is the L1Block predeploy method that the V1 L1 info deposit calls
+var L1InfoV1Method = func() abi.Method {
+	parsed, err := l1block.L1blockMetaData.GetAbi()
+	if err != nil {
+		panic(fmt.Errorf("failed to load L1Block ABI: %v", err))
+	}
+	method, ok := parsed.Methods["setL1BlockValuesV1"]
+	if !ok {
+		panic("L1Block ABI is missing setL1BlockValuesV1")
+	}
+	return method
+}()
+
+type L1Info interface {
+	NumberU64() uint64
+	Time() uint64
+	Hash() common.Hash
+	BaseFee() *big.Int
+	// MixDigest field, reused for randomness after The Merge (Bellatrix hardfork)
+	MixDigest() common.Hash
+}
+
+// L1BlockInfo presents the information stored in the L1Block predeploy by a L1 info deposit
+type L1BlockInfo struct {
+	// Version of the L1 info encoding, L1InfoV0 does not carry the fields below the block hash
+	Version uint8
+
+	Number    uint64
+	Time      uint64
+	BaseFee   *big.Int
+	BlockHash common.Hash
+
+	// SequenceNumber is the number of L2 blocks since the start of the epoch,
+	// i.e. 0 for the first L2 block that is derived from the L1 block.
+	SequenceNumber uint64
+	// BatcherAddr is the batch submitter authorized at the time of the L1 block,
+	// the first of the batch senders of the L1 block in the batcher schedule
+	BatcherAddr common.Address
+	// L1FeeOverhead and L1FeeScalar parametrize the L1 data fee of L2 transactions
+	L1FeeOverhead *big.Int
+	L1FeeScalar   *big.Int
+}
+
+// MarshalBinary encodes the L1 info as calldata of the L1 info deposit, following the Version of the info.
+func (info *L1BlockInfo) MarshalBinary() ([]byte, error) {
+	bigOrZero := func(v *big.Int) *big.Int {
+		if v == nil {
+			return new(big.Int)
+		}
+		return v
+	}
+	switch info.Version {
+	case L1InfoV0:
+		data := make([]byte, l1InfoV0Len)
+		offset := 0
+		copy(data[offset:4], L1InfoFuncBytes4)
+		offset += 4
+		binary.BigEndian.PutUint64(data[offset:offset+8], info.Number)
+		offset += 8
+		binary.BigEndian.PutUint64(data[offset:offset+8], info.Time)
+		offset += 8
+		baseFee := bigOrZero(info.BaseFee)
+		if baseFee.Sign() < 0 || baseFee.BitLen() > 256 {
+			return nil, fmt.Errorf("basefee does not fit in uint256: %s", baseFee)
+		}
+		baseFee.FillBytes(data[offset : offset+32])
+		offset += 32
+		copy(data[offset:offset+32], info.BlockHash.Bytes())
+		return data, nil
+	case L1InfoV1:
+		args, err := L1InfoV1Method.Inputs.Pack(
+			info.Number,
+			info.Time,
+			bigOrZero(info.BaseFee),
+			info.BlockHash,
+			info.SequenceNumber,
+			info.BatcherAddr,
+			bigOrZero(info.L1FeeOverhead),
+			bigOrZero(info.L1FeeScalar),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode v1 L1 info: %v", err)
+		}
+		return append(append(make([]byte, 0, 4+len(args)), L1InfoV1Method.ID...), args...), nil
+	default:
+		return nil, fmt.Errorf("unrecognized L1 info version: %d", info.Version)
+	}
+}
+
+// UnmarshalBinary decodes the calldata of a L1 info deposit of any version.
+func (info *L1BlockInfo) UnmarshalBinary(data []byte) error {
+	if len(data) < 4 {
+		return fmt.Errorf("data is too short to contain a function selector: %d bytes", len(data))
+	}
+	switch {
+	case bytes.Equal(data[:4], L1InfoFuncBytes4):
+		if len(data) != l1InfoV0Len {
+			return fmt.Errorf("v0 L1 info data is unexpected length: %d", len(data))
+		}
+		*info = L1BlockInfo{Version: L1InfoV0}
+		offset := 4
+		info.Number = binary.BigEndian.Uint64(data[offset : offset+8])
+		offset += 8
+		info.Time = binary.BigEndian.Uint64(data[offset : offset+8])
+		offset += 8
+		info.BaseFee = new(big.Int).SetBytes(data[offset : offset+32])
+		offset += 32
+		info.BlockHash.SetBytes(data[offset : offset+32])
+		return nil
+	case bytes.Equal(data[:4], L1InfoV1Method.ID):
+		if len(data) != 4+8*32 {
+			return fmt.Errorf("v1 L1 info data is unexpected length: %d", len(data))
+		}
+		var args struct {
+			Number         uint64
+			Timestamp      uint64
+			Basefee        *big.Int
+			Hash           [32]byte
+			SequenceNumber uint64
+			Batcher        common.Address
+			L1FeeOverhead  *big.Int
+			L1FeeScalar    *big.Int
+		}
+		values, err := L1InfoV1Method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return fmt.Errorf("failed to decode v1 L1 info: %v", err)
+		}
+		if err := L1InfoV1Method.Inputs.Copy(&args, values); err != nil {
+			return fmt.Errorf("failed to read v1 L1 info: %v", err)
+		}
+		*info = L1BlockInfo{
+			Version:        L1InfoV1,
+			Number:         args.Number,
+			Time:           args.Timestamp,
+			BaseFee:        args.Basefee,
+			BlockHash:      args.Hash,
+			SequenceNumber: args.SequenceNumber,
+			BatcherAddr:    args.Batcher,
+			L1FeeOverhead:  args.L1FeeOverhead,
+			L1FeeScalar:    args.L1FeeScalar,
+		}
+		// The ABI decoder tolerates dirty padding of the uint64 and address fields, only accept the canonical encoding.
+		canonical, err := info.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(canonical, data) {
+			return fmt.Errorf("v1 L1 info data is not canonically encoded: %x", data)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unrecognized L1 info function selector: %x", data[:4])
+	}
+}
+
+// L1InfoDepositTxData is the inverse of L1InfoDeposit, to see where the L2 chain is derived from
+func L1InfoDepositTxData(data []byte) (L1BlockInfo, error) {
+	var info L1BlockInfo
+	err := info.UnmarshalBinary(data)
+	return info, err
+}
+
+// L1InfoVersion returns the L1 info version of the L2 block with the given timestamp.
+func L1InfoVersion(cfg *rollup.Config, l2Time uint64) uint8 {
+	if cfg.IsActive(rollup.L1InfoV1Fork, l2Time) {
+		return L1InfoV1
+	}
+	return L1InfoV0
+}
+
+// L1InfoDeposit creates a L1 Info deposit transaction based on the L1 block,
+// and the L2 block-height difference with the start of the epoch.
+// The L2 block timestamp determines the version of the L1 info.
+func L1InfoDeposit(seqNumber uint64, block L1Info, l2Time uint64, cfg *rollup.Config) (*types.DepositTx, error) {
+	info := L1BlockInfo{
+		Version:        L1InfoVersion(cfg, l2Time),
+		Number:         block.NumberU64(),
+		Time:           block.Time(),
+		BaseFee:        block.BaseFee(),
+		BlockHash:      block.Hash(),
+		SequenceNumber: seqNumber,
+		BatcherAddr:    cfg.BatchSenders(block.NumberU64())[0], // the canonical batcher, see rollup.BatcherUpdate
+		L1FeeOverhead:  new(big.Int).SetUint64(cfg.L1FeeOverhead),
+		L1FeeScalar:    new(big.Int).SetUint64(cfg.L1FeeScalar),
+	}
+	data, err := info.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &types.DepositTx{
+		BlockHeight:      block.NumberU64(),
+		TransactionIndex: 0, // always the first transaction
+		From:             DepositContractAddr,
+		To:               &L1InfoPredeployAddr,
+		Mint:             nil,
+		Value:            big.NewInt(0),
+		Gas:              99_999_999,
+		Data:             data,
+	}, nil
+}
+
+// L1InfoDepositBytes returns a serialized L1-info attributes transaction.
+func L1InfoDepositBytes(seqNumber uint64, l1Info L1Info, l2Time uint64, cfg *rollup.Config) (l2.Data, error) {
+	dep, err := L1InfoDeposit(seqNumber, l1Info, l2Time, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L1 info tx: %v", err)
+	}
+	l1Tx := types.NewTx(dep)
+	opaqueL1Tx, err := l1Tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode L1 info tx")
+	}
+	return opaqueL1Tx, nil
+}
@@ -0,0 +1,93 @@
+package derive
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/l1block"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/stretchr/testify/require"
+)
+
+// TestL1InfoDepositPredeploy runs L1 info deposits against the code of the L1Block predeploy,
+// and asserts that the predeploy stores the L1 info.
+func TestL1InfoDepositPredeploy(t *testing.T) {
+	parsed, err := l1block.L1blockMetaData.GetAbi()
+	require.NoError(t, err)
+
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetCode(L1InfoPredeployAddr, common.FromHex(l1block.L1blockDeployedBin))
+
+	call := func(from common.Address, data []byte) ([]byte, error) {
+		ret, _, err := runtime.Call(L1InfoPredeployAddr, data, &runtime.Config{
+			Origin:   from,
+			GasLimit: 99_999_999,
+			State:    statedb,
+		})
+		return ret, err
+	}
+	get := func(name string) interface{} {
+		data, err := parsed.Pack(name)
+		require.NoError(t, err)
+		ret, err := call(common.Address{}, data)
+		require.NoError(t, err, name)
+		out, err := parsed.Unpack(name, ret)
+		require.NoError(t, err, name)
+		return out[0]
+	}
+
+	cfg := &rollup.Config{
+		BatchSenderAddress: common.Address{0xba},
+		L1FeeOverhead:      2100,
+		L1FeeScalar:        1_000_000,
+		Forks:              rollup.ForkTimes{rollup.L1InfoV1Fork: 0},
+	}
+	l1Block := types.NewBlockWithHeader(&types.Header{
+		Number:  big.NewInt(123),
+		Time:    1000,
+		BaseFee: big.NewInt(7),
+	})
+	dep, err := L1InfoDeposit(3, l1Block, 1002, cfg)
+	require.NoError(t, err)
+	require.Equal(t, L1InfoV1Method.ID, dep.Data[:4])
+
+	_, err = call(dep.From, dep.Data)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(123), get("number"))
+	require.Equal(t, big.NewInt(1000), get("timestamp"))
+	require.Equal(t, big.NewInt(7), get("basefee"))
+	require.Equal(t, [32]byte(l1Block.Hash()), get("hash"))
+	require.Equal(t, uint64(3), get("sequenceNumber"))
+	require.Equal(t, cfg.BatchSenderAddress, get("batcher"))
+	require.Equal(t, big.NewInt(2100), get("l1FeeOverhead"))
+	require.Equal(t, big.NewInt(1_000_000), get("l1FeeScalar"))
+	require.Equal(t, DepositContractAddr, get("DEPOSITOR_ACCOUNT"))
+
+	t.Run("only depositor", func(t *testing.T) {
+		ret, err := call(common.Address{0xaa}, dep.Data)
+		require.ErrorIs(t, err, vm.ErrExecutionReverted)
+		require.Equal(t, parsed.Errors["OnlyDepositor"].ID.Bytes()[:4], ret)
+	})
+	t.Run("dirty padding", func(t *testing.T) {
+		data := append([]byte{}, dep.Data...)
+		data[4] = 0x01 // the number does not fit in uint64
+		_, err := call(dep.From, data)
+		require.ErrorIs(t, err, vm.ErrExecutionReverted)
+	})
+	t.Run("legacy method", func(t *testing.T) {
+		data, err := parsed.Pack("setL1BlockValues", big.NewInt(124), big.NewInt(1012), big.NewInt(8), [32]byte{0x01})
+		require.NoError(t, err)
+		_, err = call(dep.From, data)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(124), get("number"))
+		require.Equal(t, [32]byte{0x01}, get("hash"))
+		require.Equal(t, uint64(3), get("sequenceNumber"), "V1 fields are retained")
+	})
+}
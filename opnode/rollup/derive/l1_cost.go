@@ -0,0 +1,46 @@
+package derive
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// L1FeeScalarDecimals is the number of decimals of the L1 fee scalar: a scalar of 1_000_000 charges exactly the L1 cost.
+const L1FeeScalarDecimals = 6
+
+var l1FeeScalarDivisor = new(big.Int).Exp(big.NewInt(10), big.NewInt(L1FeeScalarDecimals), nil)
+
+// RollupDataGas computes the L1 calldata gas of the given opaque (RLP / typed-tx encoded) transaction,
+// following the L1 calldata pricing of zero and non-zero bytes.
+func RollupDataGas(tx []byte) (gas uint64) {
+	for _, b := range tx {
+		if b == 0 {
+			gas += params.TxDataZeroGas
+		} else {
+			gas += params.TxDataNonZeroGasEIP2028
+		}
+	}
+	return gas
+}
+
+// L1Cost computes the L1 data fee of the given opaque L2 transaction, based on the L1 info of the L2 block it is part of:
+//
+//	l1Cost = (RollupDataGas(tx) + l1FeeOverhead) * l1BaseFee * l1FeeScalar / 10**L1FeeScalarDecimals
+//
+// Deposits are paid for on L1 and have no L1 data fee.
+// Legacy (L1InfoV0) L1 info does not carry fee parameters, and results in a zero cost.
+func L1Cost(tx []byte, info *L1BlockInfo) *big.Int {
+	if len(tx) > 0 && tx[0] == types.DepositTxType {
+		return new(big.Int)
+	}
+	if info.BaseFee == nil || info.L1FeeOverhead == nil || info.L1FeeScalar == nil {
+		return new(big.Int)
+	}
+	cost := new(big.Int).SetUint64(RollupDataGas(tx))
+	cost.Add(cost, info.L1FeeOverhead)
+	cost.Mul(cost, info.BaseFee)
+	cost.Mul(cost, info.L1FeeScalar)
+	return cost.Div(cost, l1FeeScalarDivisor)
+}
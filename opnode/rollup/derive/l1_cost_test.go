@@ -0,0 +1,39 @@
+package derive
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupDataGas(t *testing.T) {
+	require.Equal(t, uint64(0), RollupDataGas(nil))
+	require.Equal(t, uint64(4+16), RollupDataGas([]byte{0x00, 0x01}))
+	require.Equal(t, uint64(3*16+2*4), RollupDataGas([]byte{0xff, 0x00, 0x10, 0x00, 0x01}))
+}
+
+func TestL1Cost(t *testing.T) {
+	info := &L1BlockInfo{
+		Version:       L1InfoV1,
+		BaseFee:       big.NewInt(1000),
+		L1FeeOverhead: big.NewInt(100),
+		L1FeeScalar:   big.NewInt(1_500_000),
+	}
+	tx := []byte{0x02, 0x00, 0x00, 0xaa} // 2 non-zero, 2 zero bytes
+	// (2*16 + 2*4 + 100) * 1000 * 1.5
+	require.Equal(t, big.NewInt(210_000), L1Cost(tx, info))
+
+	t.Run("deposit", func(t *testing.T) {
+		require.Zero(t, L1Cost([]byte{types.DepositTxType, 0x01}, info).Sign())
+	})
+	t.Run("legacy info", func(t *testing.T) {
+		v0 := &L1BlockInfo{Version: L1InfoV0, BaseFee: big.NewInt(1000)}
+		require.Zero(t, L1Cost(tx, v0).Sign())
+	})
+	t.Run("rounds down", func(t *testing.T) {
+		small := &L1BlockInfo{Version: L1InfoV1, BaseFee: big.NewInt(1), L1FeeOverhead: new(big.Int), L1FeeScalar: big.NewInt(1)}
+		require.Zero(t, L1Cost(tx, small).Sign())
+	})
+}
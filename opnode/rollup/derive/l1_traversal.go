@@ -0,0 +1,81 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// L1Input is the data of a single L1 block that the rollup is derived from
+type L1Input struct {
+	Info         L1Info
+	Receipts     []*types.Receipt
+	Transactions []*types.Transaction
+}
+
+func (in *L1Input) ID() eth.BlockID {
+	return eth.BlockID{Hash: in.Info.Hash(), Number: in.Info.NumberU64()}
+}
+
+// SequencingWindow is the L1 data that a single epoch is derived from:
+// the L1 info and deposits of the first L1 block (the epoch),
+// and the transactions of every L1 block in the window, which may contain batches for the epoch.
+type SequencingWindow struct {
+	Epoch    L1Info
+	Receipts []*types.Receipt
+	// Transactions of every L1 block in the window, by block: Transactions[i] are the transactions of L1 block
+	// Epoch.NumberU64() + i.
+	Transactions [][]*types.Transaction
+}
+
+func (w *SequencingWindow) EpochID() eth.BlockID {
+	return eth.BlockID{Hash: w.Epoch.Hash(), Number: w.Epoch.NumberU64()}
+}
+
+// L1Traversal is the first stage of the derivation: it walks the L1 chain, one epoch at a time.
+type L1Traversal interface {
+	// NextWindow returns the sequencing window of the next epoch.
+	// io.EOF is returned when there is no complete sequencing window available.
+	NextWindow(ctx context.Context) (*SequencingWindow, error)
+}
+
+// L1InputTraversal traverses a contiguous range of in-memory L1 inputs.
+type L1InputTraversal struct {
+	inputs     []*L1Input
+	windowSize uint64
+	next       uint64
+}
+
+var _ L1Traversal = (*L1InputTraversal)(nil)
+
+func NewL1InputTraversal(inputs []*L1Input, windowSize uint64) *L1InputTraversal {
+	return &L1InputTraversal{inputs: inputs, windowSize: windowSize}
+}
+
+func (tr *L1InputTraversal) NextWindow(ctx context.Context) (*SequencingWindow, error) {
+	if tr.windowSize == 0 {
+		return nil, fmt.Errorf("invalid sequencing window size: %d", tr.windowSize)
+	}
+	if tr.next+tr.windowSize > uint64(len(tr.inputs)) {
+		return nil, io.EOF
+	}
+	window := tr.inputs[tr.next : tr.next+tr.windowSize]
+	for i := 1; i < len(window); i++ {
+		if window[i].Info.NumberU64() != window[i-1].Info.NumberU64()+1 {
+			return nil, fmt.Errorf("L1 inputs are not contiguous: %s is followed by %s", window[i-1].ID(), window[i].ID())
+		}
+	}
+	txs := make([][]*types.Transaction, 0, len(window))
+	for _, in := range window {
+		txs = append(txs, in.Transactions)
+	}
+	tr.next += 1
+	return &SequencingWindow{
+		Epoch:        window[0].Info,
+		Receipts:     window[0].Receipts,
+		Transactions: txs,
+	}, nil
+}
@@ -2,12 +2,14 @@ package derive
 
 import (
 	"bytes"
-	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/deposit"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -41,9 +43,11 @@ var (
 // Deposits additionally get:
 //  - blockNum matching the L1 block height
 //  - txIndex: matching the deposit index, not L1 transaction index, since there can be multiple deposits per L1 tx
+//
+// Any log that is not a canonical ABI encoding of the event is rejected with an error, the decoding never panics.
 func UnmarshalLogEvent(blockNum uint64, txIndex uint64, ev *types.Log) (*types.DepositTx, error) {
 	if len(ev.Topics) != 3 {
-		return nil, fmt.Errorf("expected 3 event topics (event identity, indexed from, indexed to)")
+		return nil, fmt.Errorf("expected 3 event topics (event identity, indexed from, indexed to), got %d", len(ev.Topics))
 	}
 	if ev.Topics[0] != DepositEventABIHash {
 		return nil, fmt.Errorf("invalid deposit event selector: %s, expected %s", ev.Topics[0], DepositEventABIHash)
@@ -51,6 +55,9 @@ func UnmarshalLogEvent(blockNum uint64, txIndex uint64, ev *types.Log) (*types.D
 	if len(ev.Data) < 6*32 {
 		return nil, fmt.Errorf("deposit event data too small (%d bytes): %x", len(ev.Data), ev.Data)
 	}
+	if len(ev.Data)%32 != 0 {
+		return nil, fmt.Errorf("deposit event data is not a multiple of 32 bytes: %d bytes", len(ev.Data))
+	}
 
 	var dep types.DepositTx
 
@@ -58,39 +65,62 @@ func UnmarshalLogEvent(blockNum uint64, txIndex uint64, ev *types.Log) (*types.D
 	dep.TransactionIndex = txIndex
 
 	// indexed 0
-	dep.From = common.BytesToAddress(ev.Topics[1][12:])
+	from, err := topicAddress(ev.Topics[1])
+	if err != nil {
+		return nil, fmt.Errorf("bad from address topic: %v", err)
+	}
+	dep.From = from
 	// indexed 1
-	to := common.BytesToAddress(ev.Topics[2][12:])
+	to, err := topicAddress(ev.Topics[2])
+	if err != nil {
+		return nil, fmt.Errorf("bad to address topic: %v", err)
+	}
 
 	// unindexed data
 	offset := uint64(0)
-	dep.Value = new(big.Int).SetBytes(ev.Data[offset : offset+32])
-	offset += 32
-
 	dep.Mint = new(big.Int).SetBytes(ev.Data[offset : offset+32])
 	// 0 mint is represented as nil to skip minting code
-	if dep.Mint.Cmp(new(big.Int)) == 0 {
+	if dep.Mint.Sign() == 0 {
 		dep.Mint = nil
 	}
 	offset += 32
 
+	dep.Value = new(big.Int).SetBytes(ev.Data[offset : offset+32])
+	offset += 32
+
 	gas := new(big.Int).SetBytes(ev.Data[offset : offset+32])
 	if !gas.IsUint64() {
 		return nil, fmt.Errorf("bad gas value: %x", ev.Data[offset:offset+32])
 	}
 	offset += 32
 	dep.Gas = gas.Uint64()
+
 	// isCreation: If the boolean byte is 1 then dep.To will stay nil,
 	// and it will create a contract using L2 account nonce to determine the created address.
-	if ev.Data[offset+31] == 0 {
+	isCreation := ev.Data[offset : offset+32]
+	for _, b := range isCreation[:31] {
+		if b != 0 {
+			return nil, fmt.Errorf("bad isCreation bool value: %x", isCreation)
+		}
+	}
+	switch isCreation[31] {
+	case 0:
 		dep.To = &to
+	case 1:
+		if to != (common.Address{}) {
+			return nil, fmt.Errorf("contract creation deposit must have a zero to address, got %s", to)
+		}
+	default:
+		return nil, fmt.Errorf("bad isCreation bool value: %x", isCreation)
 	}
 	offset += 32
+
 	var dataOffset uint256.Int
 	dataOffset.SetBytes(ev.Data[offset : offset+32])
 	offset += 32
-	if dataOffset.Eq(uint256.NewInt(128)) {
-		return nil, fmt.Errorf("incorrect data offset: %v", dataOffset[0])
+	// the dynamic data follows directly after the 5 static head elements
+	if !dataOffset.Eq(uint256.NewInt(5 * 32)) {
+		return nil, fmt.Errorf("incorrect data offset: %s, expected %d", dataOffset.String(), 5*32)
 	}
 
 	var dataLen uint256.Int
@@ -100,12 +130,15 @@ func UnmarshalLogEvent(blockNum uint64, txIndex uint64, ev *types.Log) (*types.D
 	if !dataLen.IsUint64() {
 		return nil, fmt.Errorf("data too large: %s", dataLen.String())
 	}
-	// The data may be padded to a multiple of 32 bytes
+	// The data is padded to a multiple of 32 bytes
 	maxExpectedLen := uint64(len(ev.Data)) - offset
 	dataLenU64 := dataLen.Uint64()
 	if dataLenU64 > maxExpectedLen {
 		return nil, fmt.Errorf("data length too long: %d, expected max %d", dataLenU64, maxExpectedLen)
 	}
+	if paddedLen := (dataLenU64 + 31) / 32 * 32; paddedLen != maxExpectedLen {
+		return nil, fmt.Errorf("data length %d does not match remaining padded event data of %d bytes", dataLenU64, maxExpectedLen)
+	}
 
 	// remaining bytes fill the data
 	dep.Data = ev.Data[offset : offset+dataLenU64]
@@ -113,39 +146,53 @@ func UnmarshalLogEvent(blockNum uint64, txIndex uint64, ev *types.Log) (*types.D
 	return &dep, nil
 }
 
-type L1Info interface {
-	NumberU64() uint64
-	Time() uint64
-	Hash() common.Hash
-	BaseFee() *big.Int
-	// MixDigest field, reused for randomness after The Merge (Bellatrix hardfork)
-	MixDigest() common.Hash
+// topicAddress decodes an indexed address event topic, rejecting topics with non-zero padding.
+func topicAddress(topic common.Hash) (common.Address, error) {
+	for _, b := range topic[:12] {
+		if b != 0 {
+			return common.Address{}, fmt.Errorf("address topic has non-zero padding: %s", topic)
+		}
+	}
+	return common.BytesToAddress(topic[12:]), nil
 }
 
-// L1InfoDeposit creats a L1 Info deposit transaction based on the L1 block
-func L1InfoDeposit(block L1Info) *types.DepositTx {
-	data := make([]byte, 4+8+8+32+32)
-	offset := 0
-	copy(data[offset:4], L1InfoFuncBytes4)
-	offset += 4
-	binary.BigEndian.PutUint64(data[offset:offset+8], block.NumberU64())
-	offset += 8
-	binary.BigEndian.PutUint64(data[offset:offset+8], block.Time())
-	offset += 8
-	block.BaseFee().FillBytes(data[offset : offset+32])
-	offset += 32
-	copy(data[offset:offset+32], block.Hash().Bytes())
-
-	return &types.DepositTx{
-		BlockHeight:      block.NumberU64(),
-		TransactionIndex: 0, // always the first transaction
-		From:             DepositContractAddr,
-		To:               &L1InfoPredeployAddr,
-		Mint:             nil,
-		Value:            big.NewInt(0),
-		Gas:              99_999_999,
-		Data:             data,
+// MarshalDepositLogEvent returns an EVM log entry that encodes a TransactionDeposited event from the deposit contract.
+// This is the inverse of UnmarshalLogEvent, the data is encoded with the ABI of the deposit contract bindings.
+func MarshalDepositLogEvent(depositContractAddr common.Address, dep *types.DepositTx) (*types.Log, error) {
+	depositABI, err := deposit.DepositMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load deposit contract ABI: %v", err)
+	}
+	ev, ok := depositABI.Events["TransactionDeposited"]
+	if !ok {
+		return nil, errors.New("deposit contract ABI is missing the TransactionDeposited event")
+	}
+	if ev.ID != DepositEventABIHash {
+		return nil, fmt.Errorf("deposit contract ABI event %s does not match expected event %s", ev.ID, DepositEventABIHash)
+	}
+
+	to := common.Address{}
+	if dep.To != nil {
+		to = *dep.To
 	}
+	mint := dep.Mint
+	if mint == nil {
+		mint = new(big.Int)
+	}
+	value := dep.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	data, err := ev.Inputs.NonIndexed().Pack(mint, value, new(big.Int).SetUint64(dep.Gas), dep.To == nil, dep.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode deposit event data: %v", err)
+	}
+
+	return &types.Log{
+		Address: depositContractAddr,
+		Topics:  []common.Hash{ev.ID, dep.From.Hash(), to.Hash()},
+		Data:    data,
+	}, nil
 }
 
 type ReceiptHash interface {
@@ -181,66 +228,140 @@ func UserDeposits(height uint64, receipts []*types.Receipt) ([]*types.DepositTx,
 	return out, nil
 }
 
-func BatchesFromEVMTransactions(config *rollup.Config, txs []*types.Transaction) ([]*BatchData, error) {
-	var out []*BatchData
+// BatchCandidate is a batch read from a batch inbox transaction, or a batch inbox transaction that was rejected
+// before its batches could be read.
+type BatchCandidate struct {
+	// Number of the L1 block that includes the transaction
+	L1Block uint64
+	TxHash  common.Hash
+	// Sender of the transaction, zero if the signature is invalid
+	Sender common.Address
+	// Index of the batch in the bundle of the transaction
+	Index int
+	// Batch is nil if the transaction was rejected
+	Batch *BatchData
+	// Err is the reason that the transaction or batch was rejected, nil if it is accepted
+	Err error
+}
+
+// BatchCandidates reads the batches of the batch inbox transactions of the L1 block with the given number.
+// Transactions with a bad signature, of a sender that is not authorized in that block, or with a bundle that
+// fails to decode, are returned as candidates without batch, with the reason of the rejection.
+func BatchCandidates(config *rollup.Config, l1Num uint64, txs []*types.Transaction) []*BatchCandidate {
+	var out []*BatchCandidate
 	l1Signer := config.L1Signer()
 	for _, tx := range txs {
-		if to := tx.To(); to != nil && *to == config.BatchInboxAddress {
-			seqDataSubmitter, err := l1Signer.Sender(tx)
-			if err != nil {
-				// TODO: log error
-				continue // bad signature, ignore
-			}
-			// some random L1 user might have sent a transaction to our batch inbox, ignore them
-			if seqDataSubmitter != config.BatchSenderAddress {
-				// TODO: log/record metric
-				continue // not an authorized batch submitter, ignore
-			}
-			batches, err := DecodeBatches(config, bytes.NewReader(tx.Data()))
-			if err != nil {
-				// TODO: log/record metric
-				continue
-			}
-			out = append(out, batches...)
+		if to := tx.To(); to == nil || *to != config.BatchInboxAddress {
+			continue
+		}
+		candidate := &BatchCandidate{L1Block: l1Num, TxHash: tx.Hash()}
+		seqDataSubmitter, err := l1Signer.Sender(tx)
+		if err != nil {
+			candidate.Err = fmt.Errorf("bad signature: %v", err)
+			out = append(out, candidate)
+			continue
+		}
+		candidate.Sender = seqDataSubmitter
+		// some random L1 user might have sent a transaction to our batch inbox, ignore them
+		if !config.IsBatchSender(l1Num, seqDataSubmitter) {
+			candidate.Err = fmt.Errorf("unauthorized sender: %s is not a batch sender in L1 block %d", seqDataSubmitter, l1Num)
+			out = append(out, candidate)
+			continue
+		}
+		batches, err := DecodeBatches(config, bytes.NewReader(tx.Data()))
+		if err != nil {
+			candidate.Err = fmt.Errorf("failed to decode bundle: %v", err)
+			out = append(out, candidate)
+			continue
+		}
+		for i, batch := range batches {
+			out = append(out, &BatchCandidate{
+				L1Block: l1Num,
+				TxHash:  candidate.TxHash,
+				Sender:  seqDataSubmitter,
+				Index:   i,
+				Batch:   batch,
+			})
+		}
+	}
+	return out
+}
+
+// BatchesFromEVMTransactions decodes the batches of the batch inbox transactions of the L1 block with the given number,
+// submitted by a batch sender that is authorized in that block.
+func BatchesFromEVMTransactions(config *rollup.Config, l1Num uint64, txs []*types.Transaction) ([]*BatchData, error) {
+	var out []*BatchData
+	for _, candidate := range BatchCandidates(config, l1Num, txs) {
+		// TODO: log/record metric of rejected transactions
+		if candidate.Err == nil {
+			out = append(out, candidate.Batch)
 		}
 	}
 	return out, nil
 }
 
-func FilterBatches(config *rollup.Config, epoch rollup.Epoch, minL2Time uint64, maxL2Time uint64, batches []*BatchData) (out []*BatchData) {
-	uniqueTime := make(map[uint64]struct{})
-	for _, batch := range batches {
-		if !ValidBatch(batch, config, epoch, minL2Time, maxL2Time) {
+func FilterBatches(config *rollup.Config, epoch eth.BlockID, minL2Time uint64, maxL2Time uint64, batches []*BatchData) (out []*BatchData) {
+	for i, err := range CheckBatches(config, epoch, minL2Time, maxL2Time, batches) {
+		if err == nil {
+			out = append(out, batches[i])
+		}
+	}
+	return
+}
+
+// CheckBatches returns for every batch the reason that FilterBatches drops it, or nil if the batch is kept.
+func CheckBatches(config *rollup.Config, epoch eth.BlockID, minL2Time uint64, maxL2Time uint64, batches []*BatchData) []error {
+	errs := make([]error, len(batches))
+	uniqueTime := make(map[uint64]int)
+	for i, batch := range batches {
+		if err := CheckBatch(batch, config, epoch, minL2Time, maxL2Time); err != nil {
+			errs[i] = err
 			continue
 		}
 		// Check if we have already seen a batch for this L2 block
-		if _, ok := uniqueTime[batch.Timestamp]; ok {
+		if first, ok := uniqueTime[batch.Timestamp]; ok {
 			// block already exists, batch is duplicate (first batch persists, others are ignored)
+			errs[i] = fmt.Errorf("duplicate: batch %d already has timestamp %d", first, batch.Timestamp)
 			continue
 		}
-		uniqueTime[batch.Timestamp] = struct{}{}
-		out = append(out, batch)
+		uniqueTime[batch.Timestamp] = i
 	}
-	return
+	return errs
 }
 
-func ValidBatch(batch *BatchData, config *rollup.Config, epoch rollup.Epoch, minL2Time uint64, maxL2Time uint64) bool {
-	if batch.Epoch != epoch {
+func ValidBatch(batch *BatchData, config *rollup.Config, epoch eth.BlockID, minL2Time uint64, maxL2Time uint64) bool {
+	return CheckBatch(batch, config, epoch, minL2Time, maxL2Time) == nil
+}
+
+// CheckBatch returns why the batch is not valid in the epoch and L2 time range, or nil if it is valid.
+func CheckBatch(batch *BatchData, config *rollup.Config, epoch eth.BlockID, minL2Time uint64, maxL2Time uint64) error {
+	if uint64(batch.Epoch) != epoch.Number {
 		// Batch was tagged for past or future epoch,
 		// i.e. it was included too late or depends on the given L1 block to be processed first.
-		return false
+		return fmt.Errorf("wrong epoch: batch has epoch %d, expected %d", batch.Epoch, epoch.Number)
+	}
+	if expected := BatchVersion(config, batch.Timestamp); batch.Version != expected {
+		// batch version does not match the forks active at the batch timestamp
+		return fmt.Errorf("wrong version: batch has version %d, expected %d at timestamp %d",
+			batch.Version, expected, batch.Timestamp)
+	}
+	if batch.Version == BatchV2Type && batch.EpochHash != epoch.Hash {
+		// batch was created for a different L1 chain
+		return fmt.Errorf("wrong epoch hash: batch has epoch hash %s, expected %s", batch.EpochHash, epoch.Hash)
 	}
 	if (batch.Timestamp-config.Genesis.L2Time)%config.BlockTime != 0 {
-		return false // bad timestamp, not a multiple of the block time
+		// bad timestamp, not a multiple of the block time
+		return fmt.Errorf("bad timestamp: %d is not aligned with the block time %d since the L2 genesis time %d",
+			batch.Timestamp, config.BlockTime, config.Genesis.L2Time)
 	}
 	if batch.Timestamp < minL2Time {
-		return false // old batch
+		return fmt.Errorf("too old: timestamp %d is before the next L2 block time %d", batch.Timestamp, minL2Time)
 	}
 	// limit timestamp upper bound to avoid huge amount of empty blocks
 	if batch.Timestamp >= maxL2Time {
-		return false // too far in future
+		return fmt.Errorf("too new: timestamp %d is not before the L1 time %d of the epoch", batch.Timestamp, maxL2Time)
 	}
-	return true
+	return nil
 }
 
 type L2Info interface {
@@ -260,7 +381,7 @@ func SortedAndPreparedBatches(batches []*BatchData, epoch, blockTime, minL2Time,
 			out = append(out, b)
 		} else {
 			out = append(out, &BatchData{
-				BatchV1{
+				BatchV1: BatchV1{
 					Epoch:     rollup.Epoch(epoch),
 					Timestamp: t,
 				},
@@ -270,15 +391,6 @@ func SortedAndPreparedBatches(batches []*BatchData, epoch, blockTime, minL2Time,
 	return out
 }
 
-func L1InfoDepositBytes(l1Info L1Info) (l2.Data, error) {
-	l1Tx := types.NewTx(L1InfoDeposit(l1Info))
-	opaqueL1Tx, err := l1Tx.MarshalBinary()
-	if err != nil {
-		return nil, fmt.Errorf("failed to encode L1 info tx")
-	}
-	return opaqueL1Tx, nil
-}
-
 func DeriveDeposits(epoch uint64, receipts []*types.Receipt) ([]l2.Data, error) {
 	userDeposits, err := UserDeposits(epoch, receipts)
 	if err != nil {
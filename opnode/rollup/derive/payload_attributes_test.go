@@ -1,7 +1,6 @@
 package derive
 
 import (
-	"encoding/binary"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -38,6 +37,10 @@ func GenerateDeposit(blockNum uint64, txIndex uint64, rng *rand.Rand) *types.Dep
 	var mint *big.Int
 	if rng.Intn(2) == 0 {
 		mint = RandETH(rng, 200)
+		// 0 mint is represented as nil
+		if mint.Sign() == 0 {
+			mint = nil
+		}
 	}
 
 	dep := &types.DepositTx{
@@ -54,44 +57,13 @@ func GenerateDeposit(blockNum uint64, txIndex uint64, rng *rand.Rand) *types.Dep
 }
 
 // Generates an EVM log entry that encodes a TransactionDeposited event from the deposit contract.
-// Calls GenerateDeposit with random number generator to generate the deposit.
+// The event data is ABI encoded with the deposit contract bindings, see MarshalDepositLogEvent.
 func GenerateDepositLog(deposit *types.DepositTx) *types.Log {
-
-	toBytes := common.Hash{}
-	if deposit.To != nil {
-		toBytes = deposit.To.Hash()
-	}
-	topics := []common.Hash{
-		DepositEventABIHash,
-		deposit.From.Hash(),
-		toBytes,
-	}
-
-	data := make([]byte, 6*32)
-	offset := 0
-	deposit.Value.FillBytes(data[offset : offset+32])
-	offset += 32
-
-	if deposit.Mint != nil {
-		deposit.Mint.FillBytes(data[offset : offset+32])
+	log, err := MarshalDepositLogEvent(DepositContractAddr, deposit)
+	if err != nil {
+		panic(err)
 	}
-	offset += 32
-
-	binary.BigEndian.PutUint64(data[offset+24:offset+32], deposit.Gas)
-	offset += 32
-	if deposit.To == nil { // isCreation
-		data[offset+31] = 1
-	}
-	offset += 32
-	binary.BigEndian.PutUint64(data[offset+24:offset+32], 5*32)
-	offset += 32
-	binary.BigEndian.PutUint64(data[offset+24:offset+32], uint64(len(deposit.Data)))
-	data = append(data, deposit.Data...)
-	if len(data)%32 != 0 { // pad to multiple of 32
-		data = append(data, make([]byte, 32-(len(data)%32))...)
-	}
-
-	return GenerateLog(DepositContractAddr, topics, data)
+	return log
 }
 
 // Generates an EVM log entry with the given topics and data.
@@ -128,7 +100,7 @@ func TestUnmarshalLogEvent(t *testing.T) {
 	}
 }
 
-// DeriveL1InfoDeposit is tested in reading_test.go, combined with the inverse ParseL1InfoDepositTxData
+// L1InfoDeposit is tested in invert_test.go, combined with the inverse L1InfoDepositTxData
 
 // receiptData defines what a test receipt looks like
 type receiptData struct {
@@ -0,0 +1,162 @@
+package derive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// TestVector is a derivation conformance test: given the rollup config, the L2 parent
+// and a contiguous range of L1 blocks, a rollup node must derive the expected payload attributes.
+// Test vectors are JSON encoded, to be shared with other rollup node implementations.
+type TestVector struct {
+	Name   string        `json:"name"`
+	Config rollup.Config `json:"config"`
+	// L2 chain state that the derivation starts from
+	Parent L2Parent `json:"l2_parent"`
+	// L1 blocks to derive from, ordered by number
+	L1 []*VectorL1Block `json:"l1"`
+	// Payload attributes of every L2 block, grouped by epoch
+	Expected []*EpochAttributes `json:"expected"`
+}
+
+// VectorL1Block is a L1 block, as consumed by the derivation.
+type VectorL1Block struct {
+	Header       *types.Header        `json:"header"`
+	Transactions []*types.Transaction `json:"transactions"`
+	Receipts     []*types.Receipt     `json:"receipts"`
+}
+
+// headerInfo presents a L1 header as L1 info for the derivation
+type headerInfo struct {
+	header *types.Header
+}
+
+func (h headerInfo) NumberU64() uint64      { return h.header.Number.Uint64() }
+func (h headerInfo) Time() uint64           { return h.header.Time }
+func (h headerInfo) Hash() common.Hash      { return h.header.Hash() }
+func (h headerInfo) BaseFee() *big.Int      { return h.header.BaseFee }
+func (h headerInfo) MixDigest() common.Hash { return h.header.MixDigest }
+
+func (b *VectorL1Block) Input() *L1Input {
+	return &L1Input{
+		Info:         headerInfo{b.Header},
+		Receipts:     b.Receipts,
+		Transactions: b.Transactions,
+	}
+}
+
+// checkL1 verifies the L1 blocks form a chain, and the transactions and receipts match the headers.
+func (v *TestVector) checkL1() error {
+	if len(v.L1) == 0 {
+		return errors.New("test vector has no L1 blocks")
+	}
+	for i, b := range v.L1 {
+		if b.Header == nil || b.Header.Number == nil {
+			return fmt.Errorf("L1 block %d has no header", i)
+		}
+		if i > 0 {
+			prev := v.L1[i-1].Header
+			if b.Header.ParentHash != prev.Hash() || b.Header.Number.Uint64() != prev.Number.Uint64()+1 {
+				return fmt.Errorf("L1 block %d (%d) does not build on the previous block (%d)", i, b.Header.Number, prev.Number)
+			}
+		}
+		if txRoot := types.DeriveSha(types.Transactions(b.Transactions), trie.NewStackTrie(nil)); txRoot != b.Header.TxHash {
+			return fmt.Errorf("L1 block %d transactions do not match transactions root: %s <> %s", b.Header.Number, txRoot, b.Header.TxHash)
+		}
+		if !CheckReceipts(types.NewBlockWithHeader(b.Header), b.Receipts) {
+			return fmt.Errorf("L1 block %d receipts do not match receipts root %s", b.Header.Number, b.Header.ReceiptHash)
+		}
+	}
+	return nil
+}
+
+func (v *TestVector) inputs() []*L1Input {
+	inputs := make([]*L1Input, len(v.L1))
+	for i, b := range v.L1 {
+		inputs[i] = b.Input()
+	}
+	return inputs
+}
+
+// Run derives the payload attributes from the L1 blocks of the test vector, and checks them against the expected attributes.
+func (v *TestVector) Run() error {
+	if err := v.Config.Check(); err != nil {
+		return fmt.Errorf("invalid rollup config of test vector %q: %w", v.Name, err)
+	}
+	if err := v.checkL1(); err != nil {
+		return fmt.Errorf("invalid test vector %q: %w", v.Name, err)
+	}
+	out, err := DeriveAttributes(&v.Config, v.Parent, v.inputs())
+	if err != nil {
+		return fmt.Errorf("failed to derive attributes of test vector %q: %w", v.Name, err)
+	}
+	if len(out) != len(v.Expected) {
+		return fmt.Errorf("test vector %q: expected %d epochs, derived %d", v.Name, len(v.Expected), len(out))
+	}
+	for i := range out {
+		got, err := json.Marshal(out[i])
+		if err != nil {
+			return err
+		}
+		expected, err := json.Marshal(v.Expected[i])
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(got, expected) {
+			return fmt.Errorf("test vector %q: epoch %d mismatch:\nexpected: %s\ngot:      %s", v.Name, i, expected, got)
+		}
+	}
+	return nil
+}
+
+// VectorL1Source is the L1 data source that test vectors are recorded from, satisfied by the ethclient.
+type VectorL1Source interface {
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// RecordTestVector fetches the inclusive L1 block range from the source, and records the attributes
+// that are derived from it on top of the given L2 parent as a test vector.
+func RecordTestVector(ctx context.Context, name string, config *rollup.Config, parent L2Parent, src VectorL1Source, from, to uint64) (*TestVector, error) {
+	if from > to {
+		return nil, fmt.Errorf("invalid L1 range %d..%d", from, to)
+	}
+	v := &TestVector{Name: name, Config: *config, Parent: parent}
+	for num := from; num <= to; num++ {
+		block, err := src.BlockByNumber(ctx, new(big.Int).SetUint64(num))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L1 block %d: %w", num, err)
+		}
+		receipts := make([]*types.Receipt, 0, len(block.Transactions()))
+		for _, tx := range block.Transactions() {
+			rec, err := src.TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch receipt of tx %s in L1 block %d: %w", tx.Hash(), num, err)
+			}
+			receipts = append(receipts, rec)
+		}
+		v.L1 = append(v.L1, &VectorL1Block{
+			Header:       block.Header(),
+			Transactions: block.Transactions(),
+			Receipts:     receipts,
+		})
+	}
+	if err := v.checkL1(); err != nil {
+		return nil, fmt.Errorf("recorded inconsistent L1 data: %w", err)
+	}
+	expected, err := DeriveAttributes(config, parent, v.inputs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive attributes: %w", err)
+	}
+	v.Expected = expected
+	return v, nil
+}
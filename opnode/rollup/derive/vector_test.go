@@ -0,0 +1,75 @@
+package derive
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestVector(t *testing.T, path string) *TestVector {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var v TestVector
+	require.NoError(t, json.Unmarshal(data, &v), "failed to decode test vector %s", path)
+	return &v
+}
+
+// TestVectors runs all derivation test vectors in testdata/vectors.
+// New vectors can be recorded with opnode/cmd/vectorgen, or by running the system e2e test with OPNODE_VECTOR_DIR set.
+func TestVectors(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "vectors", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths, "expected test vectors")
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			v := loadTestVector(t, path)
+			require.NoError(t, v.Run())
+		})
+	}
+}
+
+func TestVectorMismatch(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "vectors", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths, "expected test vectors")
+
+	t.Run("different attributes", func(t *testing.T) {
+		v := loadTestVector(t, paths[0])
+		require.NotEmpty(t, v.Expected)
+		v.Expected[0].Attributes[0].Timestamp += 1
+		require.Error(t, v.Run())
+	})
+	t.Run("missing epoch", func(t *testing.T) {
+		v := loadTestVector(t, paths[0])
+		v.Expected = v.Expected[:len(v.Expected)-1]
+		require.Error(t, v.Run())
+	})
+	t.Run("tampered L1 transactions", func(t *testing.T) {
+		v := loadTestVector(t, paths[0])
+		for _, b := range v.L1 {
+			if len(b.Transactions) > 0 {
+				b.Transactions = b.Transactions[1:]
+				break
+			}
+		}
+		require.Error(t, v.Run())
+	})
+	t.Run("tampered L1 receipts", func(t *testing.T) {
+		v := loadTestVector(t, paths[0])
+		for _, b := range v.L1 {
+			if len(b.Receipts) > 0 {
+				b.Receipts[0].CumulativeGasUsed += 1
+				break
+			}
+		}
+		require.Error(t, v.Run())
+	})
+	t.Run("gap in L1", func(t *testing.T) {
+		v := loadTestVector(t, paths[0])
+		v.L1 = append(v.L1[:1], v.L1[2:]...)
+		require.Error(t, v.Run())
+	})
+}
@@ -48,6 +48,11 @@ func (d *Driver) Close() error {
 	return d.s.Close()
 }
 
+// SyncStatus returns the current L1 and L2 heads of the driver
+func (d *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	return d.s.SyncStatus(ctx)
+}
+
 type inputImpl struct {
 	chainSource sync.ChainSource
 	genesis     *rollup.Genesis
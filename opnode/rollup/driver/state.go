@@ -2,6 +2,7 @@ package driver
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
@@ -45,19 +46,23 @@ type state struct {
 	output  outputInterface
 	bss     BatchSubmitter
 
+	// syncStatusReq is used to request a snapshot of the chain heads from the state loop
+	syncStatusReq chan chan eth.SyncStatus
+
 	log  log.Logger
 	done chan struct{}
 }
 
 func NewState(log log.Logger, config rollup.Config, input inputInterface, output outputInterface, submitter BatchSubmitter, sequencer bool) *state {
 	return &state{
-		Config:    config,
-		done:      make(chan struct{}),
-		log:       log,
-		input:     input,
-		output:    output,
-		bss:       submitter,
-		sequencer: sequencer,
+		Config:        config,
+		done:          make(chan struct{}),
+		syncStatusReq: make(chan chan eth.SyncStatus),
+		log:           log,
+		input:         input,
+		output:        output,
+		bss:           submitter,
+		sequencer:     sequencer,
 	}
 }
 
@@ -88,6 +93,34 @@ func (s *state) Close() error {
 	return nil
 }
 
+// SyncStatus returns a snapshot of the chain heads, as tracked by the state loop.
+func (s *state) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	respCh := make(chan eth.SyncStatus, 1)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, errors.New("driver is closed")
+	case s.syncStatusReq <- respCh:
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case status := <-respCh:
+		return &status, nil
+	}
+}
+
+func (s *state) syncStatus() eth.SyncStatus {
+	return eth.SyncStatus{
+		HeadL1:      s.l1Head,
+		CurrentL1:   s.l1Base,
+		UnsafeL2:    s.l2Head,
+		SafeL2:      s.l2SafeHead,
+		FinalizedL2: s.l2Finalized,
+	}
+}
+
 // l1WindowEnd returns the last block that should be used as `base` to L1ChainWindow.
 // This is either the last block of the window, or the L1 base block if the window is not populated.
 func (s *state) l1WindowEnd() eth.BlockID {
@@ -150,6 +183,8 @@ func (s *state) loop() {
 		// case <-l2Poll.C:
 		case <-s.done:
 			return
+		case respCh := <-s.syncStatusReq:
+			respCh <- s.syncStatus()
 		case <-l2BlockCreation:
 			// 1. Check if new epoch (new L1 head)
 			firstOfEpoch := false
@@ -224,3 +224,25 @@ func TestDriver(t *testing.T) {
 	}
 
 }
+
+func TestSyncStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LvlTrace)
+	genesis := fakeGenesis('a', 'A', 0)
+	chainSource := NewFakeChainSource([]string{"abc"}, []string{"A"}, log)
+	outputHandler := func(ctx context.Context, l2Head eth.BlockID, l2Finalized eth.BlockID, l2Unsafe eth.BlockID, l1Window []eth.BlockID) (eth.BlockID, error) {
+		t.Error("Got a step when no step should have occurred")
+		return l2Head, nil
+	}
+	config := rollup.Config{SeqWindowSize: 2, Genesis: genesis, BlockTime: 2}
+	state := NewState(log, config, &inputImpl{chainSource: chainSource, genesis: &genesis}, outputHandlerFn(outputHandler), nil, false)
+	assert.NoError(t, state.Start(context.Background(), make(chan eth.L1BlockRef)), "Error starting the state object")
+
+	status, err := state.SyncStatus(context.Background())
+	assert.NoError(t, err, "Error getting the sync status")
+	assert.Equal(t, testID("a:0").ID(), status.HeadL1, "l1 head")
+	assert.Equal(t, testID("a:0").ID(), status.CurrentL1, "l1 base")
+	assert.Equal(t, testID("A:0").ID(), status.UnsafeL2, "l2 unsafe head")
+	assert.Equal(t, testID("A:0").ID(), status.SafeL2, "l2 safe head")
+
+	assert.NoError(t, state.Close(), "Error closing state")
+}
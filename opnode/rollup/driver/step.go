@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
@@ -22,8 +23,8 @@ type Downloader interface {
 	FetchL1Info(ctx context.Context, id eth.BlockID) (derive.L1Info, error)
 	// FetchReceipts of a L1 block
 	FetchReceipts(ctx context.Context, id eth.BlockID) ([]*types.Receipt, error)
-	// FetchTransactions from the given window of L1 blocks
-	FetchTransactions(ctx context.Context, window []eth.BlockID) ([]*types.Transaction, error)
+	// FetchTransactions from the given window of L1 blocks, by block
+	FetchTransactions(ctx context.Context, window []eth.BlockID) ([][]*types.Transaction, error)
 }
 
 // L2 is block preparer + BlockByHash
@@ -67,7 +68,11 @@ func (d *outputImpl) newBlock(ctx context.Context, l2Finalized eth.BlockID, l2Pa
 		}
 
 	}
-	l1InfoTx, err := derive.L1InfoDepositBytes(l1Info)
+	seqNumber, err := d.sequenceNumber(l2Info, l1Origin)
+	if err != nil {
+		return l2Parent, nil, err
+	}
+	l1InfoTx, err := derive.L1InfoDepositBytes(seqNumber, l1Info, timestamp, &d.Config)
 	if err != nil {
 		return l2Parent, nil, err
 	}
@@ -99,13 +104,7 @@ func (d *outputImpl) newBlock(ctx context.Context, l2Finalized eth.BlockID, l2Pa
 	if err != nil {
 		return l2Parent, nil, fmt.Errorf("failed to extend L2 chain: %v", err)
 	}
-	batch := &derive.BatchData{
-		BatchV1: derive.BatchV1{
-			Epoch:        rollup.Epoch(l1Info.NumberU64()),
-			Timestamp:    uint64(payload.Timestamp),
-			Transactions: payload.Transactions[depositStart:],
-		},
-	}
+	batch := derive.NewBatchData(&d.Config, l1Origin, uint64(payload.Timestamp), payload.Transactions[depositStart:])
 
 	return payload.ID(), batch, nil
 }
@@ -127,43 +126,23 @@ func (d *outputImpl) step(ctx context.Context, l2Head eth.BlockID, l2Finalized e
 	logger.Trace("Running update step on the L2 node")
 
 	// Get inputs from L1 and L2
-	epoch := rollup.Epoch(l1Input[0].Number)
 	fetchCtx, cancel := context.WithTimeout(ctx, time.Second*20)
 	defer cancel()
 	l2Info, err := d.l2.BlockByHash(fetchCtx, l2Head.Hash)
 	if err != nil {
 		return l2Head, fmt.Errorf("failed to fetch L2 block info of %s: %w", l2Head, err)
 	}
-	l1Info, err := d.dl.FetchL1Info(fetchCtx, l1Input[0])
+	l2Ref, err := derive.BlockReferences(l2Info, &d.Config.Genesis)
 	if err != nil {
-		return l2Head, fmt.Errorf("failed to fetch L1 block info of %s: %w", l1Input[0], err)
+		return l2Head, fmt.Errorf("failed to determine L1 origin of L2 head %s: %w", l2Head, err)
 	}
-	l1InfoTx, err := derive.L1InfoDepositBytes(l1Info)
+	parent := derive.L2Parent{Time: l2Info.Time(), L1Origin: l2Ref.L1Origin, SequenceNumber: l2Ref.SequenceNumber}
+	pipeline := derive.NewPipeline(&d.Config, &windowTraversal{dl: d.dl, window: l1Input})
+	epochAttrs, err := pipeline.NextAttributes(fetchCtx, parent)
 	if err != nil {
-		return l2Head, fmt.Errorf("failed to create l1InfoTx: %w", err)
+		return l2Head, fmt.Errorf("failed to derive payload attributes: %w", err)
 	}
-	receipts, err := d.dl.FetchReceipts(fetchCtx, l1Input[0])
-	if err != nil {
-		return l2Head, fmt.Errorf("failed to fetch receipts of %s: %w", l1Input[0], err)
-	}
-	deposits, err := derive.DeriveDeposits(uint64(epoch), receipts)
-	if err != nil {
-		return l2Head, fmt.Errorf("failed to derive deposits: %w", err)
-	}
-	// TODO: with sharding the blobs may be identified in more detail than L1 block hashes
-	transactions, err := d.dl.FetchTransactions(fetchCtx, l1Input)
-	if err != nil {
-		return l2Head, fmt.Errorf("failed to fetch transactions from %s: %v", l1Input, err)
-	}
-	batches, err := derive.BatchesFromEVMTransactions(&d.Config, transactions)
-	if err != nil {
-		return l2Head, fmt.Errorf("failed to fetch create batches from transactions: %w", err)
-	}
-	// Make batches contiguous
-	minL2Time := l2Info.Time() + d.Config.BlockTime
-	maxL2Time := l1Info.Time()
-	batches = derive.FilterBatches(&d.Config, epoch, minL2Time, maxL2Time, batches)
-	batches = derive.SortedAndPreparedBatches(batches, uint64(epoch), d.Config.BlockTime, minL2Time, maxL2Time)
+	epoch := epochAttrs.Epoch
 
 	// Note: SafeBlockHash currently needs to be set b/c of Geth
 	fc := l2.ForkchoiceState{
@@ -174,24 +153,10 @@ func (d *outputImpl) step(ctx context.Context, l2Head eth.BlockID, l2Finalized e
 	updateUnsafeHead := unsafeL2Head.Hash == l2Head.Hash // If unsafe head is the same as the safe head, keep it up to date
 	// Execute each L2 block in the epoch
 	last := l2Head
-	for i, batch := range batches {
-		var txns []l2.Data
-		txns = append(txns, l1InfoTx)
-		if i == 0 {
-			txns = append(txns, deposits...)
-		}
-		txns = append(txns, batch.Transactions...)
-		attrs := &l2.PayloadAttributes{
-			Timestamp:             hexutil.Uint64(batch.Timestamp),
-			Random:                l2.Bytes32(l1Info.MixDigest()),
-			SuggestedFeeRecipient: d.Config.FeeRecipientAddress,
-			Transactions:          txns,
-			NoTxPool:              false,
-		}
-
+	for i, attrs := range epochAttrs.Attributes {
 		payload, err := d.addBlock(ctx, fc, attrs, true, updateUnsafeHead)
 		if err != nil {
-			return last, fmt.Errorf("failed to extend L2 chain at block %d/%d of epoch %d: %w", i, len(batches), epoch, err)
+			return last, fmt.Errorf("failed to extend L2 chain at block %d/%d of epoch %s: %w", i, len(epochAttrs.Attributes), epoch, err)
 		}
 		last = payload.ID()
 		fc.HeadBlockHash = last.Hash
@@ -200,6 +165,46 @@ func (d *outputImpl) step(ctx context.Context, l2Head eth.BlockID, l2Finalized e
 	return last, nil
 }
 
+// sequenceNumber determines the sequence number of the L2 block that extends l2Parent with the given L1 origin:
+// the count continues within the epoch of the parent, and resets to 0 at the start of a new epoch.
+func (d *outputImpl) sequenceNumber(l2Parent *types.Block, l1Origin eth.BlockID) (uint64, error) {
+	parentRef, err := derive.BlockReferences(l2Parent, &d.Config.Genesis)
+	if err != nil {
+		return 0, fmt.Errorf("failed to determine L1 origin of L2 parent %s: %w", l2Parent.Hash(), err)
+	}
+	parent := derive.L2Parent{Time: l2Parent.Time(), L1Origin: parentRef.L1Origin, SequenceNumber: parentRef.SequenceNumber}
+	return parent.Next(l1Origin, l2Parent.Time()+d.Config.BlockTime).SequenceNumber, nil
+}
+
+// windowTraversal is a L1 traversal over a single sequencing window, fetching the L1 data on demand.
+type windowTraversal struct {
+	dl     Downloader
+	window []eth.BlockID
+	done   bool
+}
+
+func (tr *windowTraversal) NextWindow(ctx context.Context) (*derive.SequencingWindow, error) {
+	if tr.done {
+		return nil, io.EOF
+	}
+	epoch := tr.window[0]
+	l1Info, err := tr.dl.FetchL1Info(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 block info of %s: %w", epoch, err)
+	}
+	receipts, err := tr.dl.FetchReceipts(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receipts of %s: %w", epoch, err)
+	}
+	// TODO: with sharding the blobs may be identified in more detail than L1 block hashes
+	transactions, err := tr.dl.FetchTransactions(ctx, tr.window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions from %s: %v", tr.window, err)
+	}
+	tr.done = true
+	return &derive.SequencingWindow{Epoch: l1Info, Receipts: receipts, Transactions: transactions}, nil
+}
+
 func (d *outputImpl) addBlock(ctx context.Context, fc l2.ForkchoiceState, attrs *l2.PayloadAttributes, updateSafe, updateUnsafe bool) (*l2.ExecutionPayload, error) {
 	fcRes, err := d.l2.ForkchoiceUpdate(ctx, &fc, attrs)
 	if err != nil {
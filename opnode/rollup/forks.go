@@ -0,0 +1,56 @@
+package rollup
+
+import "fmt"
+
+// Fork is a named upgrade of the derivation rules. A fork activates at the first L2 block with a timestamp at or
+// after its activation time, see Config.IsActive.
+type Fork string
+
+const (
+	// L1InfoV1Fork switches the L1 info deposit from the legacy L1InfoV0 format to the L1InfoV1 format, which adds
+	// the sequence number, the batcher address and the L1 fee parameters.
+	L1InfoV1Fork Fork = "l1_info_v1"
+	// BatchV2Fork switches batches to the BatchV2 format, which binds every batch to the hash of its L1 origin,
+	// submitted in compressed (v2) bundles.
+	BatchV2Fork Fork = "batch_v2"
+)
+
+// Forks lists all forks, in the order they must activate in.
+var Forks = []Fork{L1InfoV1Fork, BatchV2Fork}
+
+// ForkTimes are the L2 timestamps that the forks activate at. Forks without a time are not active.
+type ForkTimes map[Fork]uint64
+
+// IsActive returns true if the fork is active in the L2 block with the given timestamp.
+func (cfg *Config) IsActive(fork Fork, l2Time uint64) bool {
+	activation, ok := cfg.Forks[fork]
+	return ok && l2Time >= activation
+}
+
+// checkForks verifies that only known forks are scheduled, and that forks activate in order.
+func (cfg *Config) checkForks() error {
+	known := make(map[Fork]struct{}, len(Forks))
+	for _, fork := range Forks {
+		known[fork] = struct{}{}
+	}
+	for fork := range cfg.Forks {
+		if _, ok := known[fork]; !ok {
+			return fmt.Errorf("unknown fork %q", fork)
+		}
+	}
+	for i := 1; i < len(Forks); i++ {
+		prev, fork := Forks[i-1], Forks[i]
+		activation, ok := cfg.Forks[fork]
+		if !ok {
+			continue
+		}
+		prevActivation, ok := cfg.Forks[prev]
+		if !ok {
+			return fmt.Errorf("fork %s requires fork %s to be scheduled", fork, prev)
+		}
+		if prevActivation > activation {
+			return fmt.Errorf("fork %s at %d cannot activate before fork %s at %d", fork, activation, prev, prevActivation)
+		}
+	}
+	return nil
+}
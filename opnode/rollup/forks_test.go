@@ -0,0 +1,42 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsActive(t *testing.T) {
+	cfg := randConfig()
+	assert.False(t, cfg.IsActive(L1InfoV1Fork, 0))
+
+	cfg.Forks = ForkTimes{L1InfoV1Fork: 1000}
+	assert.False(t, cfg.IsActive(L1InfoV1Fork, 999))
+	assert.True(t, cfg.IsActive(L1InfoV1Fork, 1000))
+	assert.True(t, cfg.IsActive(L1InfoV1Fork, 1001))
+	assert.False(t, cfg.IsActive(BatchV2Fork, 2000))
+}
+
+func TestCheckForks(t *testing.T) {
+	for name, forks := range map[string]ForkTimes{
+		"none":           nil,
+		"first only":     {L1InfoV1Fork: 1000},
+		"same time":      {L1InfoV1Fork: 1000, BatchV2Fork: 1000},
+		"in order":       {L1InfoV1Fork: 1000, BatchV2Fork: 2000},
+		"before genesis": {L1InfoV1Fork: 0, BatchV2Fork: 0},
+	} {
+		cfg := randConfig()
+		cfg.Forks = forks
+		assert.NoError(t, cfg.Check(), name)
+	}
+
+	for name, forks := range map[string]ForkTimes{
+		"unknown fork":   {"unknown": 1000},
+		"out of order":   {L1InfoV1Fork: 2000, BatchV2Fork: 1000},
+		"skipped a fork": {BatchV2Fork: 1000},
+	} {
+		cfg := randConfig()
+		cfg.Forks = forks
+		assert.Error(t, cfg.Check(), name)
+	}
+}
@@ -0,0 +1,82 @@
+package rollup
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Networks are the rollup configs of known networks, by name. Select them with --network instead of writing
+// rollup.json by hand.
+var Networks = map[string]*Config{
+	// Devnet is the local devnet of ops/docker-compose.yml, also found in ops/rollup.json.
+	"devnet": {
+		Genesis: Genesis{
+			L1: eth.BlockID{
+				Hash:   common.HexToHash("0x21837b23495539c19e4b85d3d115c740c677d2609480eb67c3b2bb218a3ffd8f"),
+				Number: 0,
+			},
+			L2: eth.BlockID{
+				Hash:   common.HexToHash("0xdb81479551b5fb6388ac8e177c6344ae065685fde29b565ca606f20315042610"),
+				Number: 0,
+			},
+			// Timestamp of ops/genesis-l2.json
+			L2Time: 1643928817,
+		},
+		BlockTime:            1,
+		MaxSequencerTimeDiff: 10,
+		SeqWindowSize:        64,
+		L1ChainID:            big.NewInt(900),
+		L2ChainID:            big.NewInt(901),
+		FeeRecipientAddress:  common.Address{0xff, 0x01},
+		BatchInboxAddress:    common.Address{0xff, 0x02},
+		// Address of ops/bss-key.txt
+		BatchSenderAddress: common.HexToAddress("0xDe3829A23DF1479438622a08a116E8Eb3f620BB5"),
+		L1FeeOverhead:      2100,
+		L1FeeScalar:        1_000_000,
+		Forks: ForkTimes{
+			L1InfoV1Fork: 1643928817,
+			BatchV2Fork:  1643928817,
+		},
+	},
+}
+
+// NetworkNames returns the names of the known networks, sorted.
+func NetworkNames() []string {
+	names := make([]string, 0, len(Networks))
+	for name := range Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadNetwork returns a copy of the rollup config of the named network.
+func LoadNetwork(name string) (*Config, error) {
+	cfg, ok := Networks[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %q, known networks: %v", name, NetworkNames())
+	}
+	out := *cfg
+	out.L1ChainID = new(big.Int).Set(cfg.L1ChainID)
+	out.L2ChainID = new(big.Int).Set(cfg.L2ChainID)
+	if cfg.BatcherSchedule != nil {
+		out.BatcherSchedule = make([]BatcherUpdate, len(cfg.BatcherSchedule))
+		for i, update := range cfg.BatcherSchedule {
+			out.BatcherSchedule[i] = BatcherUpdate{
+				L1Block: update.L1Block,
+				Senders: append([]common.Address(nil), update.Senders...),
+			}
+		}
+	}
+	if cfg.Forks != nil {
+		out.Forks = make(ForkTimes, len(cfg.Forks))
+		for fork, activation := range cfg.Forks {
+			out.Forks[fork] = activation
+		}
+	}
+	return &out, nil
+}
@@ -0,0 +1,107 @@
+package rollup
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworks(t *testing.T) {
+	for _, name := range NetworkNames() {
+		cfg, err := LoadNetwork(name)
+		require.NoError(t, err)
+		require.NoError(t, cfg.Check(), name)
+
+		// The preset is copied
+		cfg.L1ChainID.SetUint64(0)
+		require.NotZero(t, Networks[name].L1ChainID.Uint64())
+	}
+	_, err := LoadNetwork("unknown")
+	require.Error(t, err)
+}
+
+// TestDevnetRollupJSON asserts that the rollup.json of the devnet under ops/ matches the devnet preset.
+func TestDevnetRollupJSON(t *testing.T) {
+	data, err := os.ReadFile("../../ops/rollup.json")
+	require.NoError(t, err)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	require.NoError(t, dec.Decode(&cfg))
+	require.Equal(t, Networks["devnet"], &cfg)
+}
+
+// jsonFields returns the JSON field names of the struct type, and the names of the fields without omitempty.
+func jsonFields(typ reflect.Type) (all []string, required []string) {
+	for i := 0; i < typ.NumField(); i++ {
+		tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")
+		all = append(all, tag[0])
+		if len(tag) == 1 || tag[1] != "omitempty" {
+			required = append(required, tag[0])
+		}
+	}
+	sort.Strings(all)
+	sort.Strings(required)
+	return all, required
+}
+
+// TestConfigSchema asserts that the JSON schema of the rollup config requires exactly the fields of the Config.
+func TestConfigSchema(t *testing.T) {
+	type object struct {
+		Properties           map[string]json.RawMessage `json:"properties"`
+		Required             []string                   `json:"required"`
+		AdditionalProperties bool                       `json:"additionalProperties"`
+	}
+	data, err := os.ReadFile("config.schema.json")
+	require.NoError(t, err)
+	var schema struct {
+		object
+		Definitions map[string]json.RawMessage `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	check := func(obj object, typ reflect.Type) {
+		var props []string
+		for name := range obj.Properties {
+			props = append(props, name)
+		}
+		sort.Strings(props)
+		sort.Strings(obj.Required)
+		all, required := jsonFields(typ)
+		require.Equal(t, all, props, typ.Name())
+		require.Equal(t, required, obj.Required, typ.Name())
+		require.False(t, obj.AdditionalProperties, typ.Name())
+	}
+	check(schema.object, reflect.TypeOf(Config{}))
+
+	var genesis object
+	require.NoError(t, json.Unmarshal(schema.Properties["genesis"], &genesis))
+	check(genesis, reflect.TypeOf(Genesis{}))
+
+	var schedule struct {
+		Items object `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(schema.Properties["batcher_schedule"], &schedule))
+	check(schedule.Items, reflect.TypeOf(BatcherUpdate{}))
+
+	var forks object
+	require.NoError(t, json.Unmarshal(schema.Properties["forks"], &forks))
+	var names []string
+	for name := range forks.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var known []string
+	for _, fork := range Forks {
+		known = append(known, string(fork))
+	}
+	sort.Strings(known)
+	require.Equal(t, known, names)
+	require.False(t, forks.AdditionalProperties)
+}
@@ -1,6 +1,7 @@
 package rollup
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
@@ -34,6 +35,8 @@ type Config struct {
 	SeqWindowSize uint64 `json:"seq_window_size"`
 	// Required to verify L1 signatures
 	L1ChainID *big.Int `json:"l1_chain_id"`
+	// Chain ID of the L2 execution engine, checked against the engine on startup
+	L2ChainID *big.Int `json:"l2_chain_id"`
 
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
@@ -42,8 +45,20 @@ type Config struct {
 	FeeRecipientAddress common.Address `json:"fee_recipient_address"`
 	// L1 address that batches are sent to
 	BatchInboxAddress common.Address `json:"batch_inbox_address"`
-	// Acceptable batch-sender address
+	// Acceptable batch-sender address, until the first update of the BatcherSchedule
 	BatchSenderAddress common.Address `json:"batch_sender_address"`
+	// Updates of the set of acceptable batch-sender addresses, ordered by L1 block, see BatchSenders
+	BatcherSchedule []BatcherUpdate `json:"batcher_schedule,omitempty"`
+
+	// L1 data fee parameters, included in the L1 info deposit of every L2 block.
+	// The overhead is added to the calldata gas of each L2 transaction,
+	// the scalar has 6 decimals: 1_000_000 charges exactly the L1 calldata cost.
+	L1FeeOverhead uint64 `json:"l1_fee_overhead"`
+	L1FeeScalar   uint64 `json:"l1_fee_scalar"`
+
+	// Activation times (L2 timestamps) of the forks of the derivation rules, see IsActive.
+	// Forks that are not listed are not active.
+	Forks ForkTimes `json:"forks,omitempty"`
 }
 
 // Check verifies that the given configuration makes sense
@@ -63,6 +78,73 @@ func (cfg *Config) Check() error {
 	if cfg.Genesis.L2.Hash == cfg.Genesis.L1.Hash {
 		return errors.New("achievement get! rollup inception: L1 and L2 genesis cannot be the same")
 	}
+	if cfg.Genesis.L2Time == 0 {
+		return errors.New("genesis l2 time cannot be 0")
+	}
+	if cfg.L1ChainID == nil {
+		return errors.New("l1 chain id cannot be empty")
+	}
+	if cfg.L2ChainID == nil {
+		return errors.New("l2 chain id cannot be empty")
+	}
+	if cfg.L1ChainID.Cmp(cfg.L2ChainID) == 0 {
+		return fmt.Errorf("l1 and l2 chain id cannot be the same, got %d", cfg.L1ChainID)
+	}
+	if cfg.BatchInboxAddress == (common.Address{}) {
+		return errors.New("batch inbox address cannot be empty")
+	}
+	if cfg.BatchSenderAddress == (common.Address{}) {
+		return errors.New("batch sender address cannot be empty")
+	}
+	if err := cfg.checkBatcherSchedule(); err != nil {
+		return err
+	}
+	if err := cfg.checkForks(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ChainSource provides the chain ID and blocks of an L1 or L2 node.
+//
+// NOTE: This is a subset of ethclient.Client.
+type ChainSource interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// CheckL1 verifies that the L1 node is on the L1 chain of the rollup.
+func (cfg *Config) CheckL1(ctx context.Context, l1 ChainSource) error {
+	chainID, err := l1.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch l1 chain id: %v", err)
+	}
+	if chainID.Cmp(cfg.L1ChainID) != 0 {
+		return fmt.Errorf("l1 node has chain id %d, rollup config expects %d", chainID, cfg.L1ChainID)
+	}
+	return nil
+}
+
+// CheckL2 verifies that the L2 execution engine is on the L2 chain of the rollup, and that it starts from the
+// genesis block and time of the rollup config.
+func (cfg *Config) CheckL2(ctx context.Context, l2 ChainSource) error {
+	chainID, err := l2.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch l2 chain id: %v", err)
+	}
+	if chainID.Cmp(cfg.L2ChainID) != 0 {
+		return fmt.Errorf("l2 engine has chain id %d, rollup config expects %d", chainID, cfg.L2ChainID)
+	}
+	genesis, err := l2.HeaderByNumber(ctx, new(big.Int).SetUint64(cfg.Genesis.L2.Number))
+	if err != nil {
+		return fmt.Errorf("failed to fetch l2 genesis block %d: %v", cfg.Genesis.L2.Number, err)
+	}
+	if genesis.Hash() != cfg.Genesis.L2.Hash {
+		return fmt.Errorf("l2 engine has genesis block %s, rollup config expects %s", genesis.Hash(), cfg.Genesis.L2.Hash)
+	}
+	if genesis.Time != cfg.Genesis.L2Time {
+		return fmt.Errorf("l2 genesis block has time %d, rollup config expects %d", genesis.Time, cfg.Genesis.L2Time)
+	}
 	return nil
 }
 
@@ -1,6 +1,7 @@
 package rollup
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 	"math/rand"
@@ -8,7 +9,9 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimistic-specs/opnode/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -31,6 +34,7 @@ func randConfig() *Config {
 		MaxSequencerTimeDiff: 100,
 		SeqWindowSize:        2,
 		L1ChainID:            big.NewInt(900),
+		L2ChainID:            big.NewInt(901),
 		FeeRecipientAddress:  randAddr(),
 		BatchInboxAddress:    randAddr(),
 		BatchSenderAddress:   randAddr(),
@@ -45,3 +49,62 @@ func TestConfigJSON(t *testing.T) {
 	assert.NoError(t, json.Unmarshal(data, &roundTripped))
 	assert.Equal(t, &roundTripped, config)
 }
+
+func TestConfigCheck(t *testing.T) {
+	assert.NoError(t, randConfig().Check())
+
+	for name, modify := range map[string]func(cfg *Config){
+		"zero block time":         func(cfg *Config) { cfg.BlockTime = 0 },
+		"small sequencing window": func(cfg *Config) { cfg.SeqWindowSize = 1 },
+		"zero genesis l2 time":    func(cfg *Config) { cfg.Genesis.L2Time = 0 },
+		"no l1 chain id":          func(cfg *Config) { cfg.L1ChainID = nil },
+		"no l2 chain id":          func(cfg *Config) { cfg.L2ChainID = nil },
+		"same chain ids":          func(cfg *Config) { cfg.L2ChainID = big.NewInt(900) },
+		"no batch inbox":          func(cfg *Config) { cfg.BatchInboxAddress = common.Address{} },
+		"no batch sender":         func(cfg *Config) { cfg.BatchSenderAddress = common.Address{} },
+	} {
+		cfg := randConfig()
+		modify(cfg)
+		assert.Error(t, cfg.Check(), name)
+	}
+}
+
+type mockChainSource struct {
+	chainID *big.Int
+	headers map[uint64]*types.Header
+}
+
+func (m *mockChainSource) ChainID(ctx context.Context) (*big.Int, error) {
+	return m.chainID, nil
+}
+
+func (m *mockChainSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	header, ok := m.headers[number.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func TestConfigCheckChains(t *testing.T) {
+	config := randConfig()
+	genesis := &types.Header{Number: big.NewInt(1337), Time: config.Genesis.L2Time}
+	config.Genesis.L2.Hash = genesis.Hash()
+
+	ctx := context.Background()
+	assert.NoError(t, config.CheckL1(ctx, &mockChainSource{chainID: big.NewInt(900)}))
+	assert.Error(t, config.CheckL1(ctx, &mockChainSource{chainID: big.NewInt(1)}))
+
+	l2 := &mockChainSource{chainID: big.NewInt(901), headers: map[uint64]*types.Header{1337: genesis}}
+	assert.NoError(t, config.CheckL2(ctx, l2))
+
+	l2.chainID = big.NewInt(900)
+	assert.Error(t, config.CheckL2(ctx, l2), "l2 chain id mismatch")
+
+	l2.chainID = big.NewInt(901)
+	config.Genesis.L2Time++
+	assert.Error(t, config.CheckL2(ctx, l2), "genesis time mismatch")
+
+	config.Genesis.L2.Number = 1
+	assert.Error(t, config.CheckL2(ctx, l2), "genesis block not found")
+}
@@ -1,49 +1,58 @@
 package opnode
 
 import (
-	"crypto/ecdsa"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/ethereum-optimism/optimistic-specs/opnode/balance"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/cliconfig"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/flags"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/node"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
 
-// NewConfig creates a Config from the provided flags or environment variables.
+// NewConfig creates a Config from the provided flags, environment variables or config file.
+// All missing flags, and then all invalid values, are reported at once.
 func NewConfig(ctx *cli.Context) (*node.Config, error) {
-	rollupConfig, err := NewRollupConfig(ctx)
-	if err != nil {
+	if err := cliconfig.CheckRequired(ctx, flags.RequiredFlags); err != nil {
 		return nil, err
 	}
 
+	var errs cliconfig.Errors
+	rollupConfig, err := NewRollupConfig(ctx)
+	errs.Add(err)
+
 	enableSequencing := ctx.GlobalBool(flags.SequencingEnabledFlag.Name)
 
-	var batchSubmitterKey *ecdsa.PrivateKey
+	var submitterSigner signer.Config
+	var submitterBalance balance.Config
 	if enableSequencing {
-		keyFile := ctx.GlobalString(flags.BatchSubmitterKeyFlag.Name)
-		if keyFile == "" {
-			return nil, errors.New("sequencer mode needs batch-submitter key")
-		}
-		// TODO we should be using encrypted keystores.
-		// Mnemonics are bad because they leak *all* keys when they leak
-		// Unencrypted keys from file are bad because they are easy to leak (and we are not checking file permissions)
-		batchSubmitterKey, err = crypto.LoadECDSA(keyFile)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read batch submitter key: %v", err)
-		}
+		submitterSigner, err = NewSubmitterSignerConfig(ctx)
+		errs.Add(err)
+		submitterBalance, err = NewSubmitterBalanceConfig(ctx)
+		errs.Add(err)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
 	}
 
 	cfg := &node.Config{
-		L1NodeAddr:       ctx.GlobalString(flags.L1NodeAddr.Name),
-		L2EngineAddrs:    ctx.GlobalStringSlice(flags.L2EngineAddrs.Name),
-		Rollup:           *rollupConfig,
+		L1NodeAddr:    ctx.GlobalString(flags.L1NodeAddr.Name),
+		L2EngineAddrs: ctx.GlobalStringSlice(flags.L2EngineAddrs.Name),
+		Rollup:        *rollupConfig,
+		RPC: node.RPCConfig{
+			ListenAddr: ctx.GlobalString(flags.RPCListenAddr.Name),
+			ListenPort: ctx.GlobalInt(flags.RPCListenPort.Name),
+		},
 		Sequencer:        enableSequencing,
-		SubmitterPrivKey: batchSubmitterKey,
+		SubmitterSigner:  submitterSigner,
+		SubmitterBalance: submitterBalance,
 	}
 	if err := cfg.Check(); err != nil {
 		return nil, err
@@ -51,8 +60,71 @@ func NewConfig(ctx *cli.Context) (*node.Config, error) {
 	return cfg, nil
 }
 
+// NewSubmitterSignerConfig selects the signer of the batch submitter: an unencrypted key file,
+// an encrypted keystore or a remote signer.
+func NewSubmitterSignerConfig(ctx *cli.Context) (signer.Config, error) {
+	var cfg signer.Config
+	if keyFile := ctx.GlobalString(flags.BatchSubmitterKeyFlag.Name); keyFile != "" {
+		// Unencrypted keys from file are easy to leak (and we are not checking file permissions),
+		// prefer the keystore or remote signer.
+		key, err := crypto.LoadECDSA(keyFile)
+		if err != nil {
+			return cfg, fmt.Errorf("failed to read batch submitter key: %v", err)
+		}
+		cfg.PrivateKey = key
+	}
+	if keystorePath := ctx.GlobalString(flags.BatchSubmitterKeystoreFlag.Name); keystorePath != "" {
+		cfg.KeystorePath = keystorePath
+		if passwordFile := ctx.GlobalString(flags.BatchSubmitterPasswordFlag.Name); passwordFile != "" {
+			password, err := os.ReadFile(passwordFile)
+			if err != nil {
+				return cfg, fmt.Errorf("failed to read batch submitter keystore password: %v", err)
+			}
+			cfg.KeystorePassword = strings.TrimRight(string(password), "\r\n")
+		}
+	}
+	if endpoint := ctx.GlobalString(flags.BatchSubmitterSignerFlag.Name); endpoint != "" {
+		cfg.Endpoint = endpoint
+		addr := ctx.GlobalString(flags.BatchSubmitterSignerAddrFlag.Name)
+		if !common.IsHexAddress(addr) {
+			return cfg, fmt.Errorf("invalid batch submitter signer address: %q", addr)
+		}
+		cfg.Address = common.HexToAddress(addr)
+	}
+	if err := cfg.Check(); err != nil {
+		return cfg, fmt.Errorf("sequencer mode needs a batch submitter signer: %v", err)
+	}
+	return cfg, nil
+}
+
+// NewSubmitterBalanceConfig parses the balance thresholds of the batch submitter, in ether.
+func NewSubmitterBalanceConfig(ctx *cli.Context) (balance.Config, error) {
+	var cfg balance.Config
+	var err error
+	cfg.WarnThreshold, err = balance.ParseEther(ctx.GlobalString(flags.BatchSubmitterBalanceWarnFlag.Name))
+	if err != nil {
+		return cfg, fmt.Errorf("invalid batch submitter balance warning threshold: %v", err)
+	}
+	cfg.MinBalance, err = balance.ParseEther(ctx.GlobalString(flags.BatchSubmitterBalanceMinFlag.Name))
+	if err != nil {
+		return cfg, fmt.Errorf("invalid batch submitter minimum balance: %v", err)
+	}
+	return cfg, nil
+}
+
+// NewRollupConfig loads the rollup config from the --rollup.config JSON file, or the preset of the --network.
 func NewRollupConfig(ctx *cli.Context) (*rollup.Config, error) {
 	rollupConfigPath := ctx.GlobalString(flags.RollupConfig.Name)
+	network := ctx.GlobalString(flags.NetworkFlag.Name)
+	switch {
+	case rollupConfigPath != "" && network != "":
+		return nil, fmt.Errorf("cannot use both --%s and --%s", flags.RollupConfig.Name, flags.NetworkFlag.Name)
+	case network != "":
+		return rollup.LoadNetwork(network)
+	case rollupConfigPath == "":
+		return nil, fmt.Errorf("missing rollup config: set --%s or --%s", flags.RollupConfig.Name, flags.NetworkFlag.Name)
+	}
+
 	file, err := os.Open(rollupConfigPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read rollup config: %v", err)
@@ -60,18 +132,20 @@ func NewRollupConfig(ctx *cli.Context) (*rollup.Config, error) {
 	defer file.Close()
 
 	var rollupConfig rollup.Config
-	if err := json.NewDecoder(file).Decode(&rollupConfig); err != nil {
+	dec := json.NewDecoder(file)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rollupConfig); err != nil {
 		return nil, fmt.Errorf("failed to decode rollup config: %v", err)
 	}
 	return &rollupConfig, nil
 }
 
-// NewLogConfig creates a log config from the provided flags or environment variables.
+// NewLogConfig creates a log config from the provided flags, environment variables or config file.
 func NewLogConfig(ctx *cli.Context) (node.LogConfig, error) {
 	cfg := node.DefaultLogConfig() // Done to set color based on terminal type
 	cfg.Level = ctx.GlobalString(flags.LogLevelFlag.Name)
 	cfg.Format = ctx.GlobalString(flags.LogFormatFlag.Name)
-	if ctx.IsSet(flags.LogColorFlag.Name) {
+	if ctx.GlobalIsSet(flags.LogColorFlag.Name) {
 		cfg.Color = ctx.GlobalBool(flags.LogColorFlag.Name)
 	}
 
@@ -0,0 +1,134 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TransactionArgs are the arguments of eth_signTransaction, for EIP-1559 transactions
+type TransactionArgs struct {
+	From                 *common.Address `json:"from"`
+	To                   *common.Address `json:"to"`
+	Gas                  *hexutil.Uint64 `json:"gas"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                *hexutil.Uint64 `json:"nonce"`
+	Data                 *hexutil.Bytes  `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// NewTransactionArgs creates the arguments to sign the transaction as the given account
+func NewTransactionArgs(from common.Address, chainID *big.Int, tx *types.Transaction) TransactionArgs {
+	gas := hexutil.Uint64(tx.Gas())
+	nonce := hexutil.Uint64(tx.Nonce())
+	data := hexutil.Bytes(tx.Data())
+	return TransactionArgs{
+		From:                 &from,
+		To:                   tx.To(),
+		Gas:                  &gas,
+		MaxFeePerGas:         (*hexutil.Big)(tx.GasFeeCap()),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
+		Value:                (*hexutil.Big)(tx.Value()),
+		Nonce:                &nonce,
+		Data:                 &data,
+		ChainID:              (*hexutil.Big)(chainID),
+	}
+}
+
+// ToTransaction returns the unsigned transaction of the arguments, or an error if any field is missing
+func (args *TransactionArgs) ToTransaction() (*types.Transaction, error) {
+	if args.Gas == nil || args.MaxFeePerGas == nil || args.MaxPriorityFeePerGas == nil ||
+		args.Nonce == nil || args.ChainID == nil {
+		return nil, errors.New("missing gas, fee, nonce or chain ID")
+	}
+	var data []byte
+	if args.Data != nil {
+		data = *args.Data
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     uint64(*args.Nonce),
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(*args.Gas),
+		To:        args.To,
+		Value:     value,
+		Data:      data,
+	}), nil
+}
+
+// SignTransactionResult is the result of eth_signTransaction
+type SignTransactionResult struct {
+	Raw hexutil.Bytes      `json:"raw"`
+	Tx  *types.Transaction `json:"tx"`
+}
+
+// RemoteSigner signs transactions with the eth_signTransaction method of a JSON-RPC signer,
+// e.g. clef or a geth node with an unlocked account.
+type RemoteSigner struct {
+	client *rpc.Client
+	addr   common.Address
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+func NewRemoteSigner(client *rpc.Client, addr common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, addr: addr}
+}
+
+// DialRemoteSigner connects to the remote signer at the endpoint, signing for the given account
+func DialRemoteSigner(ctx context.Context, endpoint string, addr common.Address) (*RemoteSigner, error) {
+	client, err := rpc.DialContext(ctx, endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer %s: %v", endpoint, err)
+	}
+	return NewRemoteSigner(client, addr), nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.addr
+}
+
+// SignTx signs the transaction with the remote signer, and verifies that the returned transaction
+// is the requested transaction, signed by the account of the signer.
+func (s *RemoteSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	if tx.Type() != types.DynamicFeeTxType {
+		return nil, fmt.Errorf("remote signer only signs dynamic fee transactions, got type %d", tx.Type())
+	}
+	var result SignTransactionResult
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", NewTransactionArgs(s.addr, chainID, tx)); err != nil {
+		return nil, fmt.Errorf("remote signer failed to sign transaction: %w", err)
+	}
+	var signed types.Transaction
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid transaction: %v", err)
+	}
+	txSigner := types.LatestSignerForChainID(chainID)
+	if txSigner.Hash(&signed) != txSigner.Hash(tx) {
+		return nil, fmt.Errorf("remote signer signed a different transaction: %s", signed.Hash())
+	}
+	sender, err := types.Sender(txSigner, &signed)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid signature: %v", err)
+	}
+	if sender != s.addr {
+		return nil, fmt.Errorf("remote signer signed for %s, expected %s", sender, s.addr)
+	}
+	return &signed, nil
+}
+
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
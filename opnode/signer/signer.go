@@ -0,0 +1,126 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs L1 transactions on behalf of a single account.
+type Signer interface {
+	// Address is the account that transactions are signed for
+	Address() common.Address
+	// SignTx signs the transaction for the given chain, and returns the signed transaction
+	SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// LocalSigner signs transactions with a private key held in memory.
+type LocalSigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+var _ Signer = (*LocalSigner)(nil)
+
+func NewLocalSigner(key *ecdsa.PrivateKey) *LocalSigner {
+	return &LocalSigner{key: key, addr: crypto.PubkeyToAddress(key.PublicKey)}
+}
+
+// LoadKeystore decrypts the JSON key file of an encrypted keystore with the passphrase.
+func LoadKeystore(path string, passphrase string) (*LocalSigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore file: %v", err)
+	}
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore file %s: %v", path, err)
+	}
+	return NewLocalSigner(key.PrivateKey), nil
+}
+
+func (s *LocalSigner) Address() common.Address {
+	return s.addr
+}
+
+func (s *LocalSigner) SignTx(ctx context.Context, chainID *big.Int, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// TransactOpts creates contract binding transaction options that sign with the signer.
+func TransactOpts(ctx context.Context, s Signer, chainID *big.Int) *bind.TransactOpts {
+	from := s.Address()
+	return &bind.TransactOpts{
+		From: from,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != from {
+				return nil, bind.ErrNotAuthorized
+			}
+			return s.SignTx(ctx, chainID, tx)
+		},
+		Context: ctx,
+	}
+}
+
+// Config selects exactly one of the signers.
+type Config struct {
+	// PrivateKey is a local private key
+	PrivateKey *ecdsa.PrivateKey
+
+	// KeystorePath is the JSON key file of an encrypted keystore, decrypted with KeystorePassword
+	KeystorePath     string
+	KeystorePassword string
+
+	// Endpoint is the JSON-RPC endpoint of a remote signer, signing for Address with eth_signTransaction
+	Endpoint string
+	Address  common.Address
+}
+
+// Check verifies that exactly one signer is configured
+func (c *Config) Check() error {
+	count := 0
+	if c.PrivateKey != nil {
+		count++
+	}
+	if c.KeystorePath != "" {
+		count++
+	}
+	if c.Endpoint != "" {
+		count++
+		if c.Address == (common.Address{}) {
+			return errors.New("remote signer needs an address to sign for")
+		}
+	}
+	switch count {
+	case 0:
+		return errors.New("no signer configured, need a private key, keystore or remote signer")
+	case 1:
+		return nil
+	default:
+		return errors.New("multiple signers configured, need exactly one private key, keystore or remote signer")
+	}
+}
+
+// NewSigner creates the configured signer. A remote signer is dialed, but not queried yet.
+func NewSigner(ctx context.Context, cfg *Config) (Signer, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	switch {
+	case cfg.PrivateKey != nil:
+		return NewLocalSigner(cfg.PrivateKey), nil
+	case cfg.KeystorePath != "":
+		return LoadKeystore(cfg.KeystorePath, cfg.KeystorePassword)
+	default:
+		return DialRemoteSigner(ctx, cfg.Endpoint, cfg.Address)
+	}
+}
@@ -0,0 +1,101 @@
+package signer_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimistic-specs/opnode/internal/testsigner"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var chainID = big.NewInt(900)
+
+func testTx() *types.Transaction {
+	to := common.Address{0xff, 0x02}
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1e9),
+		GasFeeCap: big.NewInt(2e9),
+		Gas:       100_000,
+		To:        &to,
+		Value:     big.NewInt(42),
+		Data:      []byte{0x01, 0x02, 0x03},
+	})
+}
+
+// checkSigner asserts the signer signs the test transaction for its address
+func checkSigner(t *testing.T, s signer.Signer, expected common.Address) {
+	require.Equal(t, expected, s.Address())
+	tx := testTx()
+	signed, err := s.SignTx(context.Background(), chainID, tx)
+	require.NoError(t, err)
+	txSigner := types.LatestSignerForChainID(chainID)
+	sender, err := types.Sender(txSigner, signed)
+	require.NoError(t, err)
+	require.Equal(t, expected, sender)
+	require.Equal(t, txSigner.Hash(tx), txSigner.Hash(signed))
+}
+
+func TestLocalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	checkSigner(t, signer.NewLocalSigner(key), crypto.PubkeyToAddress(key.PublicKey))
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    addr,
+		PrivateKey: key,
+	}, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "key.json")
+	require.NoError(t, os.WriteFile(path, keyJSON, 0600))
+
+	s, err := signer.NewSigner(context.Background(), &signer.Config{KeystorePath: path, KeystorePassword: "passphrase"})
+	require.NoError(t, err)
+	checkSigner(t, s, addr)
+
+	_, err = signer.LoadKeystore(path, "wrong passphrase")
+	require.Error(t, err)
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	server, err := testsigner.NewServer(key)
+	require.NoError(t, err)
+	defer server.Close()
+
+	s, err := signer.NewSigner(context.Background(), &signer.Config{Endpoint: server.Endpoint(), Address: server.Addr})
+	require.NoError(t, err)
+	checkSigner(t, s, server.Addr)
+
+	t.Run("unknown account", func(t *testing.T) {
+		s, err := signer.DialRemoteSigner(context.Background(), server.Endpoint(), common.Address{0x01})
+		require.NoError(t, err)
+		defer s.Close()
+		_, err = s.SignTx(context.Background(), chainID, testTx())
+		require.Error(t, err)
+	})
+}
+
+func TestConfigCheck(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	require.Error(t, (&signer.Config{}).Check(), "no signer")
+	require.NoError(t, (&signer.Config{PrivateKey: key}).Check())
+	require.Error(t, (&signer.Config{PrivateKey: key, KeystorePath: "key.json"}).Check(), "multiple signers")
+	require.Error(t, (&signer.Config{Endpoint: "http://localhost:8550"}).Check(), "remote signer without address")
+}
@@ -5,8 +5,7 @@ import (
 	"math/big"
 	"time"
 
-	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/deposit"
-	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/l1block"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/genesis"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -42,15 +41,14 @@ type gethConfig struct {
 
 // systemConfig holds the information necessary to create a L1 <-> Rollup <-> L2 system
 type systemConfig struct {
-	mnemonic                string
-	l1                      gethConfig
-	l2Verifier              gethConfig
-	l2Sequencer             gethConfig
-	premine                 map[string]int // Derivation path -> amount in ETH (not wei)
-	cliqueSigners           []string       // derivation path
-	depositContractAddress  string
-	l1InforPredeployAddress string
-	wallet                  *hdwallet.Wallet
+	mnemonic               string
+	l1                     gethConfig
+	l2Verifier             gethConfig
+	l2Sequencer            gethConfig
+	premine                map[string]int // Derivation path -> amount in ETH (not wei)
+	cliqueSigners          []string       // derivation path
+	depositContractAddress string
+	wallet                 *hdwallet.Wallet
 }
 
 func precompileAlloc() core.GenesisAlloc {
@@ -100,9 +98,6 @@ func initializeGenesis(cfg *systemConfig) {
 		l2Alloc[addr] = core.GenesisAccount{Balance: balance}
 	}
 
-	l1Alloc[common.HexToAddress(cfg.depositContractAddress)] = core.GenesisAccount{Code: common.FromHex(deposit.DepositDeployedBin), Balance: common.Big0}
-	l2Alloc[common.HexToAddress(cfg.l1InforPredeployAddress)] = core.GenesisAccount{Code: common.FromHex(l1block.L1blockDeployedBin), Balance: common.Big0}
-
 	genesisTimestamp := uint64(time.Now().Unix())
 
 	l1Genesis := &core.Genesis{
@@ -155,6 +150,13 @@ func initializeGenesis(cfg *systemConfig) {
 		BaseFee:    big.NewInt(7),
 	}
 
+	if err := genesis.AddL1Predeploys(l1Genesis); err != nil {
+		panic(fmt.Errorf("failed to add L1 predeploys: %w", err))
+	}
+	if err := genesis.AddL2Predeploys(l2Genesis); err != nil {
+		panic(fmt.Errorf("failed to add L2 predeploys: %w", err))
+	}
+
 	cfg.l1.ethConfig.Genesis = l1Genesis
 	cfg.l2Verifier.ethConfig.Genesis = l2Genesis
 	cfg.l2Sequencer.ethConfig.Genesis = l2Genesis
@@ -2,9 +2,12 @@ package test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,22 +16,29 @@ import (
 	"github.com/ethereum-optimism/optimistic-specs/l2os"
 	"github.com/ethereum-optimism/optimistic-specs/l2os/bindings/l2oo"
 	"github.com/ethereum-optimism/optimistic-specs/l2os/txmgr"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/client"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/contracts/deposit"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/internal/testlog"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/internal/testsigner"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/l2"
 	rollupNode "github.com/ethereum-optimism/optimistic-specs/opnode/node"
 	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/rollup/derive"
+	"github.com/ethereum-optimism/optimistic-specs/opnode/signer"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 )
 
@@ -94,9 +104,8 @@ func TestSystemE2E(t *testing.T) {
 			l2OutputHDPath:     10000000,
 			bssHDPath:          10000000,
 		},
-		cliqueSigners:           []string{"m/44'/60'/0'/0/0"},
-		depositContractAddress:  "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001",
-		l1InforPredeployAddress: "0x4242424242424242424242424242424242424242",
+		cliqueSigners:          []string{"m/44'/60'/0'/0/0"},
+		depositContractAddress: "0xdeaddeaddeaddeaddeaddeaddeaddeaddead0001",
 	}
 	// Create genesis & assign it to ethconfigs
 	initializeGenesis(cfg)
@@ -148,6 +157,11 @@ func TestSystemE2E(t *testing.T) {
 	require.Nil(t, err)
 	submitterAddress := crypto.PubkeyToAddress(bssPrivKey.PublicKey)
 
+	// The batch submitter and L2 output submitter sign with remote signers
+	bssSigner, err := testsigner.NewServer(bssPrivKey)
+	require.Nil(t, err)
+	defer bssSigner.Close()
+
 	// Account
 	ethPrivKey, err := cfg.wallet.PrivateKey(accounts.Account{
 		URL: accounts.URL{
@@ -170,10 +184,21 @@ func TestSystemE2E(t *testing.T) {
 			MaxSequencerTimeDiff: 10,
 			SeqWindowSize:        2,
 			L1ChainID:            big.NewInt(900),
+			L2ChainID:            big.NewInt(int64(cfg.l2Verifier.ethConfig.NetworkId)),
 			// TODO pick defaults
 			FeeRecipientAddress: common.Address{0xff, 0x01},
 			BatchInboxAddress:   common.Address{0xff, 0x02},
 			BatchSenderAddress:  submitterAddress,
+			L1FeeOverhead:       2100,
+			L1FeeScalar:         1_000_000,
+			Forks: rollup.ForkTimes{
+				rollup.L1InfoV1Fork: l2GenesisTime,
+				rollup.BatchV2Fork:  l2GenesisTime,
+			},
+		},
+		RPC: rollupNode.RPCConfig{
+			ListenAddr: "127.0.0.1",
+			ListenPort: 9093,
 		},
 	}
 	node, err := rollupNode.New(context.Background(), nodeCfg, testlog.Logger(t, log.LvlError))
@@ -197,13 +222,27 @@ func TestSystemE2E(t *testing.T) {
 			MaxSequencerTimeDiff: 10,
 			SeqWindowSize:        2,
 			L1ChainID:            big.NewInt(900),
+			L2ChainID:            big.NewInt(int64(cfg.l2Verifier.ethConfig.NetworkId)),
 			// TODO pick defaults
 			FeeRecipientAddress: common.Address{0xff, 0x01},
 			BatchInboxAddress:   common.Address{0xff, 0x02},
 			BatchSenderAddress:  submitterAddress,
+			L1FeeOverhead:       2100,
+			L1FeeScalar:         1_000_000,
+			Forks: rollup.ForkTimes{
+				rollup.L1InfoV1Fork: l2GenesisTime,
+				rollup.BatchV2Fork:  l2GenesisTime,
+			},
+		},
+		RPC: rollupNode.RPCConfig{
+			ListenAddr: "127.0.0.1",
+			ListenPort: 9094,
+		},
+		Sequencer: true,
+		SubmitterSigner: signer.Config{
+			Endpoint: bssSigner.Endpoint(),
+			Address:  bssSigner.Addr,
 		},
-		Sequencer:        true,
-		SubmitterPrivKey: bssPrivKey,
 	}
 	sequencer, err := rollupNode.New(context.Background(), sequenceCfg, testlog.Logger(t, log.LvlError))
 	require.Nil(t, err)
@@ -220,6 +259,9 @@ func TestSystemE2E(t *testing.T) {
 	})
 	require.Nil(t, err)
 	l2OutputAddr := crypto.PubkeyToAddress(l2OutputPrivKey.PublicKey)
+	l2OutputSigner, err := testsigner.NewServer(l2OutputPrivKey)
+	require.Nil(t, err)
+	defer l2OutputSigner.Close()
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
@@ -249,16 +291,27 @@ func TestSystemE2E(t *testing.T) {
 
 	// L2Output Submitter
 	l2OutputSubmitter, err := l2os.NewL2OutputSubmitter(l2os.Config{
-		L1EthRpc:                  endpoint(cfg.l1.nodeConfig),
-		L2EthRpc:                  endpoint(cfg.l2Verifier.nodeConfig),
-		L2OOAddress:               l2ooAddr.String(),
-		PollInterval:              5 * time.Second,
-		NumConfirmations:          1,
-		ResubmissionTimeout:       5 * time.Second,
-		SafeAbortNonceTooLowCount: 3,
-		LogLevel:                  "error",
-		Mnemonic:                  cfg.mnemonic,
-		L2OutputHDPath:            l2OutputHDPath,
+		L1EthRpc:     endpoint(cfg.l1.nodeConfig),
+		RollupRpc:    "http://127.0.0.1:9093",
+		L2OOAddress:  l2ooAddr.String(),
+		L2OOMock:     true,
+		PollInterval: 5 * time.Second,
+		LogLevel:     "error",
+		Watcher:      true,
+		DriverSections: []l2os.DriverSection{{
+			Label: "l2output",
+			Config: l2os.DriverConfig{
+				NumConfirmations:          1,
+				ResubmissionTimeout:       5 * time.Second,
+				SafeAbortNonceTooLowCount: 3,
+				MaxPendingTxs:             10,
+				FeeBumpPercent:            10,
+				FeeHistoryBlocks:          10,
+				JournalPath:               filepath.Join(t.TempDir(), "l2os-journal.json"),
+				SignerEndpoint:            l2OutputSigner.Endpoint(),
+				SignerAddress:             l2OutputSigner.Addr.String(),
+			},
+		}},
 	}, "")
 	require.Nil(t, err)
 
@@ -298,9 +351,12 @@ func TestSystemE2E(t *testing.T) {
 	defer l2HeadSub.Unsubscribe()
 
 	// Finally send TX
+	// The ETH sent along with the deposit is minted on L2, the value is then transferred to self.
 	mintAmount := big.NewInt(1_000_000_000_000)
-	tx, err = depositContract.DepositTransaction(opts, fromAddr, mintAmount, big.NewInt(1_000_000), false, nil)
+	opts.Value = mintAmount
+	tx, err = depositContract.DepositTransaction(opts, fromAddr, common.Big0, big.NewInt(1_000_000), false, nil)
 	require.Nil(t, err, "with deposit tx")
+	opts.Value = nil
 
 	// Wait for tx to be mined on L1 (or timeout)
 	select {
@@ -346,6 +402,11 @@ loop:
 	diff = diff.Sub(endBalance, startBalance)
 	require.Equal(t, diff, mintAmount, "Did not get expected balance change")
 
+	verifierRPCClient, err := rpc.DialContext(context.Background(), "http://127.0.0.1:9093")
+	require.Nil(t, err)
+	rollupClient := client.NewRollupClient(verifierRPCClient)
+	defer rollupClient.Close()
+
 	// Wait for batch submitter to update L2 output oracle.
 	timeoutCh = time.After(15 * time.Second)
 	for {
@@ -365,16 +426,26 @@ loop:
 			)
 			require.Nil(t, err)
 
-			// Fetch the corresponding L2 block and assert the committed L2
-			// output matches the block's state root.
-			//
-			// NOTE: This assertion will change once the L2 output format is
-			// finalized.
+			// Fetch the corresponding L2 output from the rollup node, and
+			// assert the committed L2 output matches it.
 			ctx, cancel = context.WithTimeout(context.Background(), time.Second)
 			defer cancel()
+			version, root, err := rollupClient.OutputAtBlock(ctx, l2ooBlockNumber)
+			require.Nil(t, err)
+			require.Equal(t, l2.OutputRootVersionV0, version)
+			require.Equal(t, root, l2.Bytes32(committedL2Output))
+
+			// Only outputs of safe L2 blocks are proposed.
+			status, err := rollupClient.SyncStatus(ctx)
+			require.Nil(t, err)
+			require.LessOrEqual(t, l2ooBlockNumber.Uint64(), status.SafeL2.Number)
+
+			// The output root commits to the state root and hash of the block,
+			// and the (empty) storage root of the withdrawals contract.
 			l2Block, err := l2Client.BlockByNumber(ctx, l2ooBlockNumber)
 			require.Nil(t, err)
-			require.Equal(t, l2Block.Root(), common.Hash(committedL2Output))
+			expected := l2.ComputeL2OutputRoot(l2.OutputRootVersionV0, l2Block.Root(), types.EmptyRootHash, l2Block.Hash())
+			require.Equal(t, expected, root)
 			break
 		}
 
@@ -399,6 +470,17 @@ loop:
 	err = l2SequencerClient.SendTransaction(context.Background(), tx)
 	require.Nil(t, err)
 
+	// The sequencer charges an L1 data fee for the transaction
+	rollupRPCClient, err := rpc.DialContext(context.Background(), "http://127.0.0.1:9094")
+	require.Nil(t, err)
+	defer rollupRPCClient.Close()
+	txBytes, err := tx.MarshalBinary()
+	require.Nil(t, err)
+	var l1Fee hexutil.Big
+	err = rollupRPCClient.CallContext(context.Background(), &l1Fee, "optimism_estimateL1Fee", hexutil.Bytes(txBytes))
+	require.Nil(t, err)
+	require.True(t, l1Fee.ToInt().Sign() > 0, "expected non-zero L1 fee")
+
 	var l2IncludedBlock *big.Int
 
 	// Wait for tx to show up in chain (on sequencer)
@@ -426,4 +508,23 @@ lastLoop:
 	require.Nil(t, err)
 	require.Equal(t, verifBlock.Hash(), seqBlock.Hash(), "Verifier and sequencer blocks not the same after including a batch tx")
 
+	// Optionally record the L1 chain and the attributes derived from it as a derivation test vector
+	if dir := os.Getenv("OPNODE_VECTOR_DIR"); dir != "" {
+		recordTestVector(t, dir, l1Client, &nodeCfg.Rollup)
+	}
+}
+
+// recordTestVector writes the L1 chain since genesis, and the expected derivation, to a test vector in the given directory.
+func recordTestVector(t *testing.T, dir string, l1Client *ethclient.Client, cfg *rollup.Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	head, err := l1Client.HeaderByNumber(ctx, nil)
+	require.Nil(t, err)
+	parent := derive.L2Parent{Time: cfg.Genesis.L2Time, L1Origin: cfg.Genesis.L1}
+	vector, err := derive.RecordTestVector(ctx, "system_e2e", cfg, parent, l1Client, cfg.Genesis.L1.Number+1, head.Number.Uint64())
+	require.Nil(t, err)
+	data, err := json.MarshalIndent(vector, "", "  ")
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(dir, "system_e2e.json"), append(data, '\n'), 0644)
+	require.Nil(t, err)
 }
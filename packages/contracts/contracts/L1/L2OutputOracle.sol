@@ -0,0 +1,114 @@
+//SPDX-License-Identifier: MIT
+pragma solidity >=0.8.10;
+
+/**
+ * @title L2OutputOracle
+ */
+contract L2OutputOracle {
+    /**
+     * The version of the contract interface, following semver. The major
+     * version changes on breaking changes of the interface.
+     */
+    string public constant version = "1.0.0";
+
+    uint256 public submissionFrequency;
+    uint256 public l2BlockTime;
+    mapping(uint256 => bytes32) public l2Outputs;
+    uint256 public historicalTotalBlocks;
+    uint256 public latestBlockTimestamp;
+    uint256 public startingBlockTimestamp;
+    address public sequencer;
+
+    /**
+     * Emitted when an L2 output is appended.
+     * @param _l2Output The L2 output of the checkpoint block.
+     * @param _l1Timestamp The L1 timestamp at which the output was appended.
+     * @param _l2timestamp The L2 block timestamp that resulted in _l2Output.
+     */
+    event L2OutputAppended(
+        bytes32 indexed _l2Output,
+        uint256 indexed _l1Timestamp,
+        uint256 indexed _l2timestamp
+    );
+
+    /**
+     * Initialize the L2OutputOracle contract.
+     * @param _submissionFrequency The desired interval in seconds at which
+     *        checkpoints must be submitted.
+     * @param _l2BlockTime The desired L2 inter-block time in seconds.
+     * @param _genesisL2Output The initial L2 output of the L2 chain.
+     * @param _historicalTotalBlocks The number of blocks that preceding the
+     *        initialization of the L2 chain.
+     * @param _sequencer The address that is allowed to append L2 outputs.
+     */
+    constructor(
+        uint256 _submissionFrequency,
+        uint256 _l2BlockTime,
+        bytes32 _genesisL2Output,
+        uint256 _historicalTotalBlocks,
+        address _sequencer
+    ) {
+        submissionFrequency = _submissionFrequency;
+        l2BlockTime = _l2BlockTime;
+        l2Outputs[block.timestamp] = _genesisL2Output; // solhint-disable not-rely-on-time
+        historicalTotalBlocks = _historicalTotalBlocks;
+        latestBlockTimestamp = block.timestamp; // solhint-disable not-rely-on-time
+        startingBlockTimestamp = block.timestamp; // solhint-disable not-rely-on-time
+        sequencer = _sequencer;
+    }
+
+    /**
+     * Accepts an L2 output checkpoint and the timestamp of the corresponding L2
+     * block. The timestamp must be equal to the current value returned by
+     * `nextTimestamp()` in order to be accepted.
+     * @param _l2Output The L2 output of the checkpoint block.
+     * @param _l2timestamp The L2 block timestamp that resulted in _l2Output.
+     * @param _l1Blockhash A block hash which must be included in the current
+     *        chain, or zero to skip the check.
+     * @param _l1Blocknumber The block number with the specified block hash.
+     */
+    function appendL2Output(
+        bytes32 _l2Output,
+        uint256 _l2timestamp,
+        bytes32 _l1Blockhash,
+        uint256 _l1Blocknumber
+    ) external {
+        require(msg.sender == sequencer, "Only the sequencer can append L2 outputs");
+        require(block.timestamp > _l2timestamp, "Cannot append L2 output in future");
+        require(_l2Output != bytes32(0), "Cannot submit empty L2 output");
+        require(_l2timestamp == nextTimestamp(), "Timestamp not equal to next expected timestamp");
+
+        if (_l1Blockhash != bytes32(0)) {
+            // This check allows the sequencer to append an output based on a
+            // given L1 block, without fear that it will be reorged out. It
+            // also reverts if the block number is more than 256 blocks behind
+            // the chain tip, as the hash is then zero.
+            require(
+                blockhash(_l1Blocknumber) == _l1Blockhash,
+                "Blockhash does not match the hash at the expected height."
+            );
+        }
+
+        l2Outputs[_l2timestamp] = _l2Output;
+        latestBlockTimestamp = _l2timestamp;
+
+        emit L2OutputAppended(_l2Output, block.timestamp, _l2timestamp);
+    }
+
+    /**
+     * Computes the timestamp of the next L2 block that needs to be
+     * checkpointed.
+     */
+    function nextTimestamp() public view returns (uint256) {
+        return latestBlockTimestamp + submissionFrequency;
+    }
+
+    /**
+     * Computes the L2 block number given a target L2 block timestamp.
+     * @param _timestamp The L2 block timestamp of the target block.
+     */
+    function computeL2BlockNumber(uint256 _timestamp) external view returns (uint256) {
+        require(_timestamp >= startingBlockTimestamp, "timestamp prior to startingBlockTimestamp");
+        return historicalTotalBlocks + (_timestamp - startingBlockTimestamp) / l2BlockTime;
+    }
+}
@@ -1,5 +1,5 @@
 //SPDX-License-Identifier: MIT
-pragma solidity 0.8.10;
+pragma solidity ^0.8.10;
 
 /**
  * @title L1Block
@@ -16,6 +16,10 @@ contract L1Block {
     uint256 public timestamp;
     uint256 public basefee;
     bytes32 public hash;
+    uint64 public sequenceNumber;
+    address public batcher;
+    uint256 public l1FeeOverhead;
+    uint256 public l1FeeScalar;
 
     function setL1BlockValues(
         uint256 _number,
@@ -32,4 +36,40 @@ contract L1Block {
         basefee = _basefee;
         hash = _hash;
     }
+
+    /**
+     * Updates the L1 block values, including the position of the L2 block within the epoch,
+     * the authorized batch submitter and the L1 fee parameters.
+     * @param _number L1 block number.
+     * @param _timestamp L1 block timestamp.
+     * @param _basefee L1 block basefee.
+     * @param _hash L1 block hash.
+     * @param _sequenceNumber Number of L2 blocks since the start of the epoch.
+     * @param _batcher Address of the batch submitter.
+     * @param _l1FeeOverhead L1 fee overhead.
+     * @param _l1FeeScalar L1 fee scalar.
+     */
+    function setL1BlockValuesV1(
+        uint64 _number,
+        uint64 _timestamp,
+        uint256 _basefee,
+        bytes32 _hash,
+        uint64 _sequenceNumber,
+        address _batcher,
+        uint256 _l1FeeOverhead,
+        uint256 _l1FeeScalar
+    ) external {
+        if (msg.sender != DEPOSITOR_ACCOUNT) {
+            revert OnlyDepositor();
+        }
+
+        number = _number;
+        timestamp = _timestamp;
+        basefee = _basefee;
+        hash = _hash;
+        sequenceNumber = _sequenceNumber;
+        batcher = _batcher;
+        l1FeeOverhead = _l1FeeOverhead;
+        l1FeeScalar = _l1FeeScalar;
+    }
 }
@@ -1,4 +1,4 @@
-pragma solidity 0.8.10;
+pragma solidity ^0.8.10;
 
 import { DSTest } from "../../lib/ds-test/src/test.sol";
 import { L1Block } from "../L2/L1Block.sol";
@@ -35,4 +35,21 @@ contract L1BLockTest is DSTest {
     function test_hash() external {
         assertEq(lb.hash(), NON_ZERO_HASH);
     }
+
+    function test_setL1BlockValuesV1() external {
+        cheats.prank(depositor);
+        lb.setL1BlockValuesV1(4, 5, 6, NON_ZERO_HASH, 7, address(0x42), 2100, 1000000);
+        assertEq(lb.number(), 4);
+        assertEq(lb.timestamp(), 5);
+        assertEq(lb.basefee(), 6);
+        assertEq(lb.hash(), NON_ZERO_HASH);
+        assertEq(lb.sequenceNumber(), 7);
+        assertEq(lb.batcher(), address(0x42));
+        assertEq(lb.l1FeeOverhead(), 2100);
+        assertEq(lb.l1FeeScalar(), 1000000);
+    }
+
+    function testFail_setL1BlockValuesV1_onlyDepositor() external {
+        lb.setL1BlockValuesV1(4, 5, 6, NON_ZERO_HASH, 7, address(0x42), 2100, 1000000);
+    }
 }
@@ -0,0 +1,108 @@
+pragma solidity ^0.8.10;
+
+import { DSTest } from "../../lib/ds-test/src/test.sol";
+import { L2OutputOracle } from "../L1/L2OutputOracle.sol";
+
+interface CheatCodes {
+    function prank(address) external;
+
+    function warp(uint256) external;
+
+    function roll(uint256) external;
+}
+
+contract L2OutputOracleTest is DSTest {
+    CheatCodes cheats = CheatCodes(HEVM_ADDRESS);
+    L2OutputOracle oracle;
+    address sequencer = address(0x42);
+    uint256 submissionFrequency = 10;
+    uint256 l2BlockTime = 2;
+    uint256 startingBlockTimestamp;
+    bytes32 immutable GENESIS_OUTPUT = keccak256(abi.encode(0));
+    bytes32 immutable NON_ZERO_OUTPUT = keccak256(abi.encode(1));
+    bytes32 immutable NON_ZERO_HASH = keccak256(abi.encode(2));
+
+    function setUp() external {
+        cheats.warp(1000);
+        cheats.roll(100);
+        oracle = new L2OutputOracle(submissionFrequency, l2BlockTime, GENESIS_OUTPUT, 5, sequencer);
+        startingBlockTimestamp = block.timestamp;
+        // Outputs can only be appended once their L2 timestamp has passed.
+        cheats.warp(block.timestamp + 100);
+        cheats.roll(block.number + 10);
+    }
+
+    function test_version() external {
+        assertEq(oracle.version(), "1.0.0");
+    }
+
+    function test_genesis() external {
+        assertEq(oracle.l2Outputs(startingBlockTimestamp), GENESIS_OUTPUT);
+        assertEq(oracle.latestBlockTimestamp(), startingBlockTimestamp);
+        assertEq(oracle.nextTimestamp(), startingBlockTimestamp + submissionFrequency);
+    }
+
+    function test_computeL2BlockNumber() external {
+        assertEq(oracle.computeL2BlockNumber(startingBlockTimestamp), 5);
+        assertEq(oracle.computeL2BlockNumber(startingBlockTimestamp + 20), 15);
+    }
+
+    function testFail_computeL2BlockNumber_priorToStart() external {
+        oracle.computeL2BlockNumber(startingBlockTimestamp - 1);
+    }
+
+    function test_appendL2Output() external {
+        uint256 first = oracle.nextTimestamp();
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, first, bytes32(0), 0);
+        assertEq(oracle.l2Outputs(first), NON_ZERO_OUTPUT);
+        assertEq(oracle.latestBlockTimestamp(), first);
+
+        // Outputs are appended in order, one submission interval apart.
+        uint256 second = oracle.nextTimestamp();
+        assertEq(second, first + submissionFrequency);
+        cheats.prank(sequencer);
+        oracle.appendL2Output(GENESIS_OUTPUT, second, bytes32(0), 0);
+        assertEq(oracle.l2Outputs(second), GENESIS_OUTPUT);
+        assertEq(oracle.latestBlockTimestamp(), second);
+    }
+
+    function testFail_appendL2Output_onlySequencer() external {
+        oracle.appendL2Output(NON_ZERO_OUTPUT, oracle.nextTimestamp(), bytes32(0), 0);
+    }
+
+    function testFail_appendL2Output_future() external {
+        uint256 next = oracle.nextTimestamp();
+        cheats.warp(next);
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, next, bytes32(0), 0);
+    }
+
+    function testFail_appendL2Output_emptyOutput() external {
+        uint256 next = oracle.nextTimestamp();
+        cheats.prank(sequencer);
+        oracle.appendL2Output(bytes32(0), next, bytes32(0), 0);
+    }
+
+    function testFail_appendL2Output_skippedTimestamp() external {
+        uint256 next = oracle.nextTimestamp() + submissionFrequency;
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, next, bytes32(0), 0);
+    }
+
+    function testFail_appendL2Output_repeatedTimestamp() external {
+        uint256 next = oracle.nextTimestamp();
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, next, bytes32(0), 0);
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, next, bytes32(0), 0);
+    }
+
+    // The output is rejected if the L1 block it was computed against has been
+    // reorged out.
+    function testFail_appendL2Output_reorgedBlockhash() external {
+        uint256 next = oracle.nextTimestamp();
+        cheats.prank(sequencer);
+        oracle.appendL2Output(NON_ZERO_OUTPUT, next, NON_ZERO_HASH, block.number - 1);
+    }
+}
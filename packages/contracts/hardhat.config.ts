@@ -13,7 +13,21 @@ task('accounts', 'Prints the list of accounts', async (taskArgs, hre) => {
 })
 
 const config: HardhatUserConfig = {
-  solidity: '0.8.10',
+  solidity: {
+    compilers: [{ version: '0.8.10' }],
+    overrides: {
+      // The Go bindings of these contracts are built with solc 0.8.21, for the
+      // London EVM of the L1 and L2 chains (without PUSH0).
+      'contracts/L2/L1Block.sol': {
+        version: '0.8.21',
+        settings: { evmVersion: 'london' },
+      },
+      'contracts/L1/L2OutputOracle.sol': {
+        version: '0.8.21',
+        settings: { evmVersion: 'london' },
+      },
+    },
+  },
   networks: {},
   gasReporter: {
     enabled: process.env.REPORT_GAS !== undefined,